@@ -0,0 +1,163 @@
+package heap
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/Jh123x/go-collections/internal/cond"
+)
+
+// ErrClosed is returned when operating on a closed queue.
+var ErrClosed = cond.ErrClosed
+
+// BlockingPriorityQueue is a thread-safe priority queue whose Pop blocks until an element is available.
+// When created with a positive capacity, Insert blocks while the queue is full.
+type BlockingPriorityQueue[T any] struct {
+	heap     *Heap[T]
+	mux      *sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	capacity int
+	closed   bool
+}
+
+// NewBlockingPriorityQueue creates a queue ordered by less.
+// A capacity of 0 or less creates an unbounded queue.
+func NewBlockingPriorityQueue[T any](less LessFn[T], capacity int) *BlockingPriorityQueue[T] {
+	mux := &sync.Mutex{}
+	return &BlockingPriorityQueue[T]{
+		heap:     NewHeap(less),
+		mux:      mux,
+		notEmpty: sync.NewCond(mux),
+		notFull:  sync.NewCond(mux),
+		capacity: capacity,
+	}
+}
+
+// Insert adds val to the queue, blocking while the queue is full.
+// Returns ErrClosed if the queue is closed or the context error if ctx is done first.
+func (q *BlockingPriorityQueue[T]) Insert(ctx context.Context, val T) error {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if err := q.wait(ctx, q.notFull, q.isFull); err != nil {
+		return err
+	}
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	q.heap.Insert(val)
+	q.notEmpty.Signal()
+	return nil
+}
+
+// TryInsert adds val to the queue without blocking.
+// Returns false if the queue is full or closed.
+func (q *BlockingPriorityQueue[T]) TryInsert(val T) bool {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if q.closed || q.isFull() {
+		return false
+	}
+
+	q.heap.Insert(val)
+	q.notEmpty.Signal()
+	return true
+}
+
+// Pop removes and returns the first element, blocking until one is available.
+// Once the queue is closed, the remaining elements are still returned before ErrClosed.
+func (q *BlockingPriorityQueue[T]) Pop(ctx context.Context) (T, error) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	var empty T
+	if err := q.wait(ctx, q.notEmpty, q.isEmpty); err != nil {
+		return empty, err
+	}
+
+	val, ok := q.heap.Pop()
+	if !ok {
+		return empty, ErrClosed
+	}
+
+	q.notFull.Signal()
+	return val, nil
+}
+
+// TryPop removes and returns the first element without blocking.
+// Returns false if the queue is empty.
+func (q *BlockingPriorityQueue[T]) TryPop() (T, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	val, ok := q.heap.Pop()
+	if ok {
+		q.notFull.Signal()
+	}
+
+	return val, ok
+}
+
+// Close stops the queue from accepting new elements and wakes up all blocked callers.
+func (q *BlockingPriorityQueue[T]) Close() {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
+
+// All returns an iterator over a snapshot of the elements in pop order.
+// The lock is not held while the consumer runs.
+func (q *BlockingPriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		q.mux.Lock()
+		snapshot := slices.Collect(q.heap.All())
+		q.mux.Unlock()
+
+		for _, v := range snapshot {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops elements without blocking until the queue is empty.
+func (q *BlockingPriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := q.TryPop()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (q *BlockingPriorityQueue[T]) Len() int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.heap.Len()
+}
+
+// wait blocks on c while blocked reports true and the queue is open.
+// The caller must hold q.mux.
+func (q *BlockingPriorityQueue[T]) wait(ctx context.Context, c *sync.Cond, blocked func() bool) error {
+	return cond.Wait(ctx, c, func() bool { return !q.closed && blocked() })
+}
+
+func (q *BlockingPriorityQueue[T]) isEmpty() bool {
+	return q.heap.Len() == 0
+}
+
+func (q *BlockingPriorityQueue[T]) isFull() bool {
+	return q.capacity > 0 && q.heap.Len() >= q.capacity
+}
@@ -0,0 +1,144 @@
+package heap
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const defaultCapacity = 5
+
+func TestBlockingPriorityQueue(t *testing.T) {
+	tests := map[string]func(t *testing.T, q *BlockingPriorityQueue[int]){
+		"Pop in priority order": func(t *testing.T, q *BlockingPriorityQueue[int]) {
+			for _, v := range []int{3, 1, 2} {
+				assert.NoError(t, q.Insert(context.Background(), v))
+			}
+
+			for _, expected := range []int{1, 2, 3} {
+				v, err := q.Pop(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, expected, v)
+			}
+		},
+		"Pop blocks until insert": func(t *testing.T, q *BlockingPriorityQueue[int]) {
+			result := make(chan int)
+			go func() {
+				v, err := q.Pop(context.Background())
+				assert.NoError(t, err)
+				result <- v
+			}()
+
+			time.Sleep(10 * time.Millisecond)
+			assert.NoError(t, q.Insert(context.Background(), 42))
+			assert.Equal(t, 42, <-result)
+		},
+		"Pop respects context": func(t *testing.T, q *BlockingPriorityQueue[int]) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			_, err := q.Pop(ctx)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		},
+		"All snapshots and Drain does not block": func(t *testing.T, q *BlockingPriorityQueue[int]) {
+			for _, v := range []int{3, 1, 2} {
+				assert.True(t, q.TryInsert(v))
+			}
+
+			for v := range q.All() {
+				// The lock is not held while iterating.
+				assert.True(t, q.TryInsert(v+10))
+				break
+			}
+			assert.Equal(t, []int{1, 2, 3, 11}, slices.Collect(q.All()))
+			assert.Equal(t, []int{1, 2, 3, 11}, slices.Collect(q.Drain()))
+			assert.Equal(t, 0, q.Len())
+		},
+		"Insert blocks when full": func(t *testing.T, q *BlockingPriorityQueue[int]) {
+			for v := range defaultCapacity {
+				assert.True(t, q.TryInsert(v))
+			}
+			assert.False(t, q.TryInsert(defaultCapacity))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			assert.ErrorIs(t, q.Insert(ctx, defaultCapacity), context.DeadlineExceeded)
+
+			done := make(chan error)
+			go func() { done <- q.Insert(context.Background(), -1) }()
+
+			v, ok := q.TryPop()
+			assert.True(t, ok)
+			assert.Equal(t, 0, v)
+			assert.NoError(t, <-done)
+
+			v, ok = q.TryPop()
+			assert.True(t, ok)
+			assert.Equal(t, -1, v)
+		},
+		"Close drains then errors": func(t *testing.T, q *BlockingPriorityQueue[int]) {
+			assert.NoError(t, q.Insert(context.Background(), 1))
+			q.Close()
+
+			assert.ErrorIs(t, q.Insert(context.Background(), 2), ErrClosed)
+			assert.False(t, q.TryInsert(2))
+
+			v, err := q.Pop(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 1, v)
+
+			_, err = q.Pop(context.Background())
+			assert.ErrorIs(t, err, ErrClosed)
+		},
+		"Close wakes blocked consumers": func(t *testing.T, q *BlockingPriorityQueue[int]) {
+			wg := sync.WaitGroup{}
+			wg.Add(defaultCapacity)
+			for range defaultCapacity {
+				go func() {
+					defer wg.Done()
+					_, err := q.Pop(context.Background())
+					assert.ErrorIs(t, err, ErrClosed)
+				}()
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			q.Close()
+			wg.Wait()
+		},
+		"Concurrent producers and consumers": func(t *testing.T, q *BlockingPriorityQueue[int]) {
+			const count = 1000
+			wg := sync.WaitGroup{}
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for v := range count {
+					assert.NoError(t, q.Insert(context.Background(), v))
+				}
+			}()
+
+			seen := make(map[int]struct{}, count)
+			go func() {
+				defer wg.Done()
+				for range count {
+					v, err := q.Pop(context.Background())
+					assert.NoError(t, err)
+					seen[v] = struct{}{}
+				}
+			}()
+
+			wg.Wait()
+			assert.Len(t, seen, count)
+			assert.Equal(t, 0, q.Len())
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc(t, NewBlockingPriorityQueue(intLess, defaultCapacity))
+		})
+	}
+}
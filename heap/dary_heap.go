@@ -0,0 +1,137 @@
+package heap
+
+import (
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	_ ComparatorQueue[int] = (*DaryHeap[int])(nil)
+)
+
+// DaryHeap is a heap ordered by less where every node has up to arity children.
+// Wider nodes make the heap shallower, trading more comparisons per level during Pop for fewer levels and better cache locality.
+type DaryHeap[T any] struct {
+	buffer []T
+	lessFn LessFn[T]
+	arity  int
+}
+
+// NewDaryHeap creates an empty heap with the given arity ordered by less.
+// Arity values below 2 are treated as 2.
+func NewDaryHeap[T any](arity int, less LessFn[T]) *DaryHeap[T] {
+	return &DaryHeap[T]{buffer: make([]T, 0), lessFn: less, arity: max(arity, 2)}
+}
+
+// NewDaryMin creates an empty heap with the given arity that pops the smallest element first.
+func NewDaryMin[T constraints.Ordered](arity int) *DaryHeap[T] {
+	return NewDaryHeap(arity, func(a, b T) bool { return a < b })
+}
+
+func (h *DaryHeap[T]) Insert(val T) {
+	h.buffer = append(h.buffer, val)
+
+	// Shift parents down into the hole instead of swapping at every level.
+	idx := len(h.buffer) - 1
+	for idx > 0 {
+		parentIdx := (idx - 1) / h.arity
+		if !h.lessFn(val, h.buffer[parentIdx]) {
+			break
+		}
+		h.buffer[idx] = h.buffer[parentIdx]
+		idx = parentIdx
+	}
+	h.buffer[idx] = val
+}
+
+func (h *DaryHeap[T]) Pop() (T, bool) {
+	len := len(h.buffer)
+	if len == 0 {
+		var empty T
+		return empty, false
+	}
+
+	result := h.buffer[0]
+	len -= 1
+	last := h.buffer[len]
+
+	var empty T
+	h.buffer[len] = empty
+	h.buffer = h.buffer[:len]
+
+	if len > 0 {
+		h.siftDown(last)
+	}
+	return result, true
+}
+
+// Peek returns the first element without removing it.
+func (h *DaryHeap[T]) Peek() (T, bool) {
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.buffer[0], true
+}
+
+// All returns an iterator over the elements in pop order without removing them.
+// The heap must not be modified while iterating.
+func (h *DaryHeap[T]) All() iter.Seq[T] {
+	less := func(i, j int) bool { return h.lessFn(h.buffer[i], h.buffer[j]) }
+	return func(yield func(T) bool) {
+		for idx := range heapOrder(len(h.buffer), h.arity, less) {
+			if !yield(h.buffer[idx]) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops the elements in pop order.
+// Stopping the iteration early leaves the remaining elements in the heap.
+func (h *DaryHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// Less reports whether a is ordered before b by the heap's comparator.
+func (h *DaryHeap[T]) Less(a, b T) bool {
+	return h.lessFn(a, b)
+}
+
+// Arity returns the maximum number of children per node.
+func (h *DaryHeap[T]) Arity() int {
+	return h.arity
+}
+
+func (h *DaryHeap[T]) Len() int {
+	return len(h.buffer)
+}
+
+// siftDown places val at the root and moves it towards the leaves.
+func (h *DaryHeap[T]) siftDown(val T) {
+	n := len(h.buffer)
+	idx := 0
+	for {
+		first := idx*h.arity + 1
+		if first >= n {
+			break
+		}
+
+		best := first
+		for child := first + 1; child < min(first+h.arity, n); child++ {
+			if h.lessFn(h.buffer[child], h.buffer[best]) {
+				best = child
+			}
+		}
+
+		if !h.lessFn(h.buffer[best], val) {
+			break
+		}
+
+		h.buffer[idx] = h.buffer[best]
+		idx = best
+	}
+	h.buffer[idx] = val
+}
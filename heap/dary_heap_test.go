@@ -0,0 +1,90 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDaryHeap(t *testing.T) {
+	for _, arity := range []int{0, 2, 3, 4, 8, 16} {
+		t.Run("arity "+strconv.Itoa(arity), func(t *testing.T) {
+			h := NewDaryMin[int](arity)
+			assert.Equal(t, max(arity, 2), h.Arity())
+
+			expected := make([]int, 0)
+			for range 1000 {
+				if len(expected) > 0 && rand.IntN(3) == 0 {
+					slices.Sort(expected)
+					v, ok := h.Pop()
+					assert.True(t, ok)
+					assert.Equal(t, expected[0], v)
+					expected = expected[1:]
+					continue
+				}
+
+				v := rand.IntN(500)
+				h.Insert(v)
+				expected = append(expected, v)
+			}
+
+			slices.Sort(expected)
+			if len(expected) > 0 {
+				v, ok := h.Peek()
+				assert.True(t, ok)
+				assert.Equal(t, expected[0], v)
+			}
+
+			for _, e := range expected {
+				v, ok := h.Pop()
+				assert.True(t, ok)
+				assert.Equal(t, e, v)
+			}
+
+			_, ok := h.Pop()
+			assert.False(t, ok)
+		})
+	}
+}
+
+func BenchmarkDaryHeap(b *testing.B) {
+	const size = 1_000_000
+	vals := make([]int, size)
+	for idx := range vals {
+		vals[idx] = rand.Int()
+	}
+
+	heaps := map[string]func() PriorityQueue[int]{
+		"MinHeap":  wrap(NewMinHeap[int]),
+		"Arity 2":  func() PriorityQueue[int] { return NewDaryMin[int](2) },
+		"Arity 4":  func() PriorityQueue[int] { return NewDaryMin[int](4) },
+		"Arity 8":  func() PriorityQueue[int] { return NewDaryMin[int](8) },
+		"Arity 16": func() PriorityQueue[int] { return NewDaryMin[int](16) },
+	}
+
+	for name, fn := range heaps {
+		b.Run(name+"/Insert", func(b *testing.B) {
+			for range b.N {
+				h := fn()
+				for _, v := range vals {
+					h.Insert(v)
+				}
+			}
+		})
+
+		b.Run(name+"/InsertPop", func(b *testing.B) {
+			for range b.N {
+				h := fn()
+				for _, v := range vals {
+					h.Insert(v)
+				}
+				for range size {
+					h.Pop()
+				}
+			}
+		})
+	}
+}
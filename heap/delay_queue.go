@@ -0,0 +1,183 @@
+package heap
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers to a DelayQueue.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type delayedItem[T any] struct {
+	val T
+	at  time.Time
+	seq uint64
+}
+
+// DelayHandle refers to an element scheduled on a DelayQueue.
+type DelayHandle[T any] struct {
+	handle Handle[delayedItem[T]]
+}
+
+// DelayQueue is a thread-safe queue whose elements only become available once their deadline has passed.
+// Elements with the same deadline are taken in the order they were scheduled.
+type DelayQueue[T any] struct {
+	heap    *IndexedHeap[delayedItem[T]]
+	mux     *sync.Mutex
+	changed chan struct{}
+	clock   Clock
+	seq     uint64
+}
+
+// NewDelayQueue creates an empty delay queue using the system clock.
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return NewDelayQueueWithClock[T](realClock{})
+}
+
+// NewDelayQueueWithClock creates an empty delay queue using clock to tell time.
+func NewDelayQueueWithClock[T any](clock Clock) *DelayQueue[T] {
+	return &DelayQueue[T]{
+		heap: NewIndexedHeap(func(a, b delayedItem[T]) bool {
+			if a.at.Equal(b.at) {
+				return a.seq < b.seq
+			}
+			return a.at.Before(b.at)
+		}),
+		mux:     &sync.Mutex{},
+		changed: make(chan struct{}),
+		clock:   clock,
+	}
+}
+
+// Schedule adds val to the queue, making it available at the given time.
+func (q *DelayQueue[T]) Schedule(val T, at time.Time) DelayHandle[T] {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	q.seq++
+	handle := q.heap.Insert(delayedItem[T]{val: val, at: at, seq: q.seq})
+	q.notify()
+
+	return DelayHandle[T]{handle: handle}
+}
+
+// Cancel removes a scheduled element from the queue.
+// Returns false if the element has already been taken or cancelled.
+func (q *DelayQueue[T]) Cancel(handle DelayHandle[T]) bool {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if _, ok := q.heap.Remove(handle.handle); !ok {
+		return false
+	}
+
+	q.notify()
+	return true
+}
+
+// Take removes and returns the element with the earliest deadline,
+// blocking until that deadline has passed or ctx is done.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mux.Lock()
+		changed := q.changed
+
+		var timer <-chan time.Time
+		if item, ok := q.heap.Peek(); ok {
+			wait := item.at.Sub(q.clock.Now())
+			if wait <= 0 {
+				q.heap.Pop()
+				q.mux.Unlock()
+				return item.val, nil
+			}
+
+			timer = q.clock.After(wait)
+		}
+		q.mux.Unlock()
+
+		select {
+		case <-ctx.Done():
+			var empty T
+			return empty, ctx.Err()
+		case <-changed:
+		case <-timer:
+		}
+	}
+}
+
+// TryTake removes and returns the element with the earliest deadline if that deadline has passed.
+func (q *DelayQueue[T]) TryTake() (T, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	item, ok := q.heap.Peek()
+	if !ok || item.at.After(q.clock.Now()) {
+		var empty T
+		return empty, false
+	}
+
+	q.heap.Pop()
+	return item.val, true
+}
+
+// Len returns the number of scheduled elements, including those that are not yet available.
+func (q *DelayQueue[T]) Len() int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.heap.Len()
+}
+
+// All returns an iterator over a snapshot of the scheduled elements in deadline order,
+// including those that are not yet available.
+// The lock is not held while the consumer runs.
+func (q *DelayQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		q.mux.Lock()
+		snapshot := make([]T, 0, q.heap.Len())
+		for item := range q.heap.All() {
+			snapshot = append(snapshot, item.val)
+		}
+		q.mux.Unlock()
+
+		for _, v := range snapshot {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that takes elements whose deadline has passed, without blocking.
+// Elements that are not yet available stay in the queue.
+func (q *DelayQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := q.TryTake()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// notify wakes up all goroutines blocked in Take.
+// The caller must hold q.mux.
+func (q *DelayQueue[T]) notify() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
@@ -0,0 +1,193 @@
+package heap
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// fakeClock only moves forward when Advance is called.
+type fakeClock struct {
+	mux     sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+}
+
+func (c *fakeClock) Waiters() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return len(c.waiters)
+}
+
+func TestDelayQueue(t *testing.T) {
+	tests := map[string]func(t *testing.T, q *DelayQueue[string], clock *fakeClock){
+		"Take returns expired items in deadline order": func(t *testing.T, q *DelayQueue[string], clock *fakeClock) {
+			now := clock.Now()
+			q.Schedule("c", now.Add(-time.Second))
+			q.Schedule("a", now.Add(-3*time.Second))
+			q.Schedule("b", now.Add(-2*time.Second))
+
+			for _, expected := range []string{"a", "b", "c"} {
+				v, err := q.Take(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, expected, v)
+			}
+		},
+		"Equal deadlines are FIFO": func(t *testing.T, q *DelayQueue[string], clock *fakeClock) {
+			at := clock.Now()
+			for _, v := range []string{"a", "b", "c"} {
+				q.Schedule(v, at)
+			}
+
+			for _, expected := range []string{"a", "b", "c"} {
+				v, ok := q.TryTake()
+				assert.True(t, ok)
+				assert.Equal(t, expected, v)
+			}
+		},
+		"All includes pending items and Drain only takes expired ones": func(t *testing.T, q *DelayQueue[string], clock *fakeClock) {
+			now := clock.Now()
+			q.Schedule("later", now.Add(time.Minute))
+			q.Schedule("b", now.Add(-time.Second))
+			q.Schedule("a", now.Add(-2*time.Second))
+
+			assert.Equal(t, []string{"a", "b", "later"}, slices.Collect(q.All()))
+			assert.Equal(t, []string{"a", "b"}, slices.Collect(q.Drain()))
+			assert.Equal(t, []string{"later"}, slices.Collect(q.All()))
+
+			clock.Advance(time.Minute)
+			assert.Equal(t, []string{"later"}, slices.Collect(q.Drain()))
+			assert.Equal(t, 0, q.Len())
+		},
+		"TryTake does not return pending items": func(t *testing.T, q *DelayQueue[string], clock *fakeClock) {
+			q.Schedule("a", clock.Now().Add(time.Minute))
+			_, ok := q.TryTake()
+			assert.False(t, ok)
+			assert.Equal(t, 1, q.Len())
+
+			clock.Advance(time.Minute)
+			v, ok := q.TryTake()
+			assert.True(t, ok)
+			assert.Equal(t, "a", v)
+		},
+		"Take waits for deadline": func(t *testing.T, q *DelayQueue[string], clock *fakeClock) {
+			q.Schedule("a", clock.Now().Add(time.Hour))
+
+			result := make(chan string)
+			go func() {
+				v, err := q.Take(context.Background())
+				assert.NoError(t, err)
+				result <- v
+			}()
+
+			assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+			clock.Advance(30 * time.Minute)
+			assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+			select {
+			case <-result:
+				t.Fatal("item should not be available yet")
+			default:
+			}
+
+			clock.Advance(30 * time.Minute)
+			assert.Equal(t, "a", <-result)
+		},
+		"Earlier schedule wakes up Take": func(t *testing.T, q *DelayQueue[string], clock *fakeClock) {
+			q.Schedule("late", clock.Now().Add(time.Hour))
+
+			result := make(chan string)
+			go func() {
+				v, err := q.Take(context.Background())
+				assert.NoError(t, err)
+				result <- v
+			}()
+
+			assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+			q.Schedule("early", clock.Now())
+			assert.Equal(t, "early", <-result)
+		},
+		"Cancel": func(t *testing.T, q *DelayQueue[string], clock *fakeClock) {
+			handle := q.Schedule("a", clock.Now())
+			q.Schedule("b", clock.Now())
+
+			assert.True(t, q.Cancel(handle))
+			assert.False(t, q.Cancel(handle))
+
+			v, ok := q.TryTake()
+			assert.True(t, ok)
+			assert.Equal(t, "b", v)
+			assert.Equal(t, 0, q.Len())
+		},
+		"Take respects context": func(t *testing.T, q *DelayQueue[string], clock *fakeClock) {
+			q.Schedule("a", clock.Now().Add(time.Hour))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := q.Take(ctx)
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Equal(t, 1, q.Len())
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			clock := newFakeClock()
+			tc(t, NewDelayQueueWithClock[string](clock), clock)
+		})
+	}
+}
+
+func TestDelayQueue_RealClock(t *testing.T) {
+	q := NewDelayQueue[int]()
+	start := time.Now()
+	q.Schedule(1, start.Add(5*time.Millisecond))
+
+	v, err := q.Take(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	assert.GreaterOrEqual(t, time.Since(start), 5*time.Millisecond)
+}
@@ -0,0 +1,147 @@
+package heap
+
+import (
+	"iter"
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	_ ComparatorQueue[int] = (*Heap[int])(nil)
+)
+
+// Heap is a binary heap ordered by a user supplied LessFn.
+// The element for which less reports true against every other element is popped first.
+type Heap[T any] struct {
+	buffer []T
+	lessFn LessFn[T]
+}
+
+// NewHeap creates an empty heap ordered by less.
+func NewHeap[T any](less LessFn[T]) *Heap[T] {
+	return &Heap[T]{buffer: make([]T, 0), lessFn: less}
+}
+
+// NewHeapFrom creates a heap ordered by less containing a copy of vals in O(n).
+func NewHeapFrom[T any](less LessFn[T], vals []T) *Heap[T] {
+	h := &Heap[T]{buffer: slices.Clone(vals), lessFn: less}
+	if h.buffer == nil {
+		h.buffer = make([]T, 0)
+	}
+
+	heapify(h, len(h.buffer))
+	return h
+}
+
+// NewMinFuncHeap creates an empty heap that pops the smallest element first.
+func NewMinFuncHeap[T constraints.Ordered]() *Heap[T] {
+	return NewHeap(func(a, b T) bool { return a < b })
+}
+
+// NewMaxFuncHeap creates an empty heap that pops the largest element first.
+func NewMaxFuncHeap[T constraints.Ordered]() *Heap[T] {
+	return NewHeap(func(a, b T) bool { return a > b })
+}
+
+func (h *Heap[T]) Insert(val T) {
+	h.buffer = append(h.buffer, val)
+	siftUp(h, len(h.buffer)-1)
+}
+
+func (h *Heap[T]) Pop() (T, bool) {
+	len := h.Len()
+	if len <= 0 {
+		var empty T
+		return empty, false
+	}
+
+	result := h.buffer[0]
+
+	len -= 1
+	h.swap(0, len)
+	siftDown(h, 0, len)
+
+	// Clear the vacated slot so the heap does not retain references.
+	var empty T
+	h.buffer[len] = empty
+	h.buffer = h.buffer[:len]
+	return result, true
+}
+
+// Peek returns the first element without removing it.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.buffer[0], true
+}
+
+// PushPop inserts val and then pops the first element.
+// It is faster than calling Insert followed by Pop.
+func (h *Heap[T]) PushPop(val T) T {
+	if len(h.buffer) == 0 || !h.lessFn(h.buffer[0], val) {
+		return val
+	}
+
+	val, h.buffer[0] = h.buffer[0], val
+	siftDown(h, 0, len(h.buffer))
+	return val
+}
+
+// Replace pops the first element and then inserts val.
+// Returns false if the heap was empty, in which case val is only inserted.
+func (h *Heap[T]) Replace(val T) (T, bool) {
+	if len(h.buffer) == 0 {
+		h.Insert(val)
+		var empty T
+		return empty, false
+	}
+
+	val, h.buffer[0] = h.buffer[0], val
+	siftDown(h, 0, len(h.buffer))
+	return val, true
+}
+
+// Clear removes all elements from the heap.
+func (h *Heap[T]) Clear() {
+	clear(h.buffer)
+	h.buffer = h.buffer[:0]
+}
+
+// All returns an iterator over the elements in pop order without removing them.
+// The heap must not be modified while iterating.
+func (h *Heap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for idx := range heapOrder(len(h.buffer), 2, h.less) {
+			if !yield(h.buffer[idx]) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops the elements in pop order.
+// Stopping the iteration early leaves the remaining elements in the heap.
+func (h *Heap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+func (h *Heap[T]) Len() int {
+	return len(h.buffer)
+}
+
+// Less reports whether a is ordered before b by the heap's comparator.
+func (h *Heap[T]) Less(a, b T) bool {
+	return h.lessFn(a, b)
+}
+
+func (h *Heap[T]) less(i, j int) bool {
+	return h.lessFn(h.buffer[i], h.buffer[j])
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.buffer[i], h.buffer[j] = h.buffer[j], h.buffer[i]
+}
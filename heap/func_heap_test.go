@@ -0,0 +1,69 @@
+package heap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type job struct {
+	name     string
+	priority int
+}
+
+func TestHeap_Comparator(t *testing.T) {
+	tests := map[string]struct {
+		heap     func() *Heap[job]
+		input    []job
+		expected []string
+	}{
+		"lowest priority first": {
+			heap: func() *Heap[job] {
+				return NewHeap(func(a, b job) bool { return a.priority < b.priority })
+			},
+			input:    []job{{"b", 2}, {"c", 3}, {"a", 1}, {"d", 4}},
+			expected: []string{"a", "b", "c", "d"},
+		},
+		"highest priority first": {
+			heap: func() *Heap[job] {
+				return NewHeap(func(a, b job) bool { return a.priority > b.priority })
+			},
+			input:    []job{{"b", 2}, {"c", 3}, {"a", 1}, {"d", 4}},
+			expected: []string{"d", "c", "b", "a"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := tc.heap()
+			for _, v := range tc.input {
+				h.Insert(v)
+			}
+
+			assert.Equal(t, len(tc.input), h.Len())
+			for _, name := range tc.expected {
+				v, ok := h.Pop()
+				assert.True(t, ok)
+				assert.Equal(t, name, v.name)
+			}
+
+			_, ok := h.Pop()
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestHeap_MaxHeap(t *testing.T) {
+	h := NewMaxFuncHeap[int]()
+	for _, v := range []int{3, 9, 1, 7, 5} {
+		h.Insert(v)
+	}
+
+	assert.True(t, h.Less(9, 1))
+	for _, expected := range []int{9, 7, 5, 3, 1} {
+		v, ok := h.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, expected, v)
+	}
+	assert.Equal(t, 0, h.Len())
+}
@@ -1,6 +1,9 @@
 package heap
 
 import (
+	"iter"
+	"slices"
+
 	"golang.org/x/exp/constraints"
 )
 
@@ -16,18 +19,20 @@ func NewMinHeap[T constraints.Ordered]() *MinHeap[T] {
 	return &MinHeap[T]{buffer: make([]T, 0)}
 }
 
+// NewMinHeapFrom creates a heap containing a copy of vals in O(n).
+func NewMinHeapFrom[T constraints.Ordered](vals []T) *MinHeap[T] {
+	p := &MinHeap[T]{buffer: slices.Clone(vals)}
+	if p.buffer == nil {
+		p.buffer = make([]T, 0)
+	}
+
+	p.heapify()
+	return p
+}
+
 func (p *MinHeap[T]) Insert(val T) {
 	p.buffer = append(p.buffer, val)
-
-	// Bubble up sequence
-	for i := len(p.buffer) - 1; i > 0; {
-		parentIdx := p.parent(i)
-		if p.buffer[i] > p.buffer[parentIdx] {
-			break
-		}
-		p.buffer[parentIdx], p.buffer[i] = p.buffer[i], p.buffer[parentIdx]
-		i = parentIdx
-	}
+	p.siftUp(len(p.buffer) - 1)
 }
 
 func (p *MinHeap[T]) Pop() (T, bool) {
@@ -38,65 +43,120 @@ func (p *MinHeap[T]) Pop() (T, bool) {
 	}
 
 	result := p.buffer[0]
-	if len == 1 {
-		p.buffer = p.buffer[:0]
-		return result, true
-	}
 
 	// Maintain heap property.
-	p.buffer[0], p.buffer[len-1] = p.buffer[len-1], p.buffer[0]
 	len -= 1
+	p.buffer[0], p.buffer[len] = p.buffer[len], p.buffer[0]
+	p.siftDown(0, len)
 
-	for start := 0; start < len; {
-		startVal := p.buffer[start]
+	p.buffer = p.buffer[:len]
+	return result, true
+}
 
-		lIdx := p.left(start)
-		rIdx := p.right(start)
+// Peek returns the smallest element without removing it.
+func (p *MinHeap[T]) Peek() (T, bool) {
+	if len(p.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
 
-		if lIdx >= len && rIdx >= len {
-			break
-		}
+	return p.buffer[0], true
+}
 
-		if rIdx >= len {
-			if p.buffer[lIdx] < startVal {
-				p.buffer[lIdx], p.buffer[start] = p.buffer[start], p.buffer[lIdx]
-			}
-			break
-		}
+// PushPop inserts val and then pops the smallest element.
+// It is faster than calling Insert followed by Pop.
+func (p *MinHeap[T]) PushPop(val T) T {
+	if len(p.buffer) == 0 || val <= p.buffer[0] {
+		return val
+	}
 
-		lVal := p.buffer[lIdx]
-		rVal := p.buffer[rIdx]
+	val, p.buffer[0] = p.buffer[0], val
+	p.siftDown(0, len(p.buffer))
+	return val
+}
 
-		if startVal < lVal && startVal < rVal {
-			break
-		}
+// Replace pops the smallest element and then inserts val.
+// Returns false if the heap was empty, in which case val is only inserted.
+func (p *MinHeap[T]) Replace(val T) (T, bool) {
+	if len(p.buffer) == 0 {
+		p.Insert(val)
+		var empty T
+		return empty, false
+	}
 
-		if lVal < rVal {
-			p.buffer[lIdx], p.buffer[start] = p.buffer[start], p.buffer[lIdx]
-			start = lIdx
-			continue
-		}
+	val, p.buffer[0] = p.buffer[0], val
+	p.siftDown(0, len(p.buffer))
+	return val, true
+}
+
+// Clear removes all elements from the heap.
+func (p *MinHeap[T]) Clear() {
+	p.buffer = p.buffer[:0]
+}
 
-		p.buffer[rIdx], p.buffer[start] = p.buffer[start], p.buffer[rIdx]
-		start = rIdx
+// All returns an iterator over the elements in ascending order without removing them.
+// The heap must not be modified while iterating.
+func (p *MinHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for idx := range heapOrder(len(p.buffer), 2, p.less) {
+			if !yield(p.buffer[idx]) {
+				return
+			}
+		}
 	}
+}
 
-	p.buffer = p.buffer[:len]
-	return result, true
+// Drain returns an iterator that pops the elements in ascending order.
+// Stopping the iteration early leaves the remaining elements in the heap.
+func (p *MinHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](p)
 }
 
 func (p *MinHeap[T]) Len() int {
 	return len(p.buffer)
 }
 
-func (p *MinHeap[T]) left(idx int) int {
-	return idx*2 + 1
+func (p *MinHeap[T]) less(i, j int) bool {
+	return p.buffer[i] < p.buffer[j]
 }
 
-func (p *MinHeap[T]) right(idx int) int {
-	return idx*2 + 2
+// siftUp moves the element at idx towards the root.
+// Like siftDown and heapify, it compares elements directly instead of using the helpers in sift.go,
+// as calls through the heapData interface cannot be inlined.
+func (p *MinHeap[T]) siftUp(idx int) {
+	for idx > 0 {
+		parentIdx := parent(idx)
+		if p.buffer[idx] >= p.buffer[parentIdx] {
+			break
+		}
+		p.buffer[idx], p.buffer[parentIdx] = p.buffer[parentIdx], p.buffer[idx]
+		idx = parentIdx
+	}
+}
+
+func (p *MinHeap[T]) siftDown(idx, n int) {
+	for {
+		lIdx := left(idx)
+		if lIdx >= n {
+			return
+		}
+
+		smallest := lIdx
+		if rIdx := right(idx); rIdx < n && p.buffer[rIdx] < p.buffer[lIdx] {
+			smallest = rIdx
+		}
+
+		if p.buffer[smallest] >= p.buffer[idx] {
+			return
+		}
+
+		p.buffer[idx], p.buffer[smallest] = p.buffer[smallest], p.buffer[idx]
+		idx = smallest
+	}
 }
 
-func (p *MinHeap[T]) parent(idx int) int {
-	return (idx - 1) / 2
+func (p *MinHeap[T]) heapify() {
+	for idx := len(p.buffer)/2 - 1; idx >= 0; idx-- {
+		p.siftDown(idx, len(p.buffer))
+	}
 }
@@ -1,21 +1,27 @@
 package heap
 
 import (
+	"iter"
 	"math/rand/v2"
+	"slices"
 	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"golang.org/x/exp/constraints"
 )
 
-func wrap[T constraints.Ordered, heap PriorityQueue[T]](fn func() heap) func() PriorityQueue[T] {
+func wrap[T any, heap PriorityQueue[T]](fn func() heap) func() PriorityQueue[T] {
 	return func() PriorityQueue[T] { return fn() }
 }
 
 func TestHeap(t *testing.T) {
 	heaps := map[string]func() PriorityQueue[int]{
-		"Heap": wrap(NewMinHeap[int]),
+		"Heap":         wrap(NewMinHeap[int]),
+		"Func Heap":    wrap(NewMinFuncHeap[int]),
+		"Pairing Heap": wrap(NewMinPairingHeap[int]),
+		"MinMax Heap":  wrap(NewOrderedMinMaxHeap[int]),
+		"Stable Heap":  wrap(NewStableMin[int]),
+		"4-ary Heap":   func() PriorityQueue[int] { return NewDaryMin[int](4) },
 	}
 	tests := map[string]func(t *testing.T, heap PriorityQueue[int]){
 		"Push Pop consecutive": testPushPop,
@@ -170,7 +176,11 @@ func testRandomSample(t *testing.T, pq PriorityQueue[int]) {
 
 func BenchmarkHeaps(b *testing.B) {
 	heaps := map[string]func() PriorityQueue[int]{
-		"Heap": wrap(NewMinHeap[int]),
+		"Heap":         wrap(NewMinHeap[int]),
+		"Func Heap":    wrap(NewMinFuncHeap[int]),
+		"Pairing Heap": wrap(NewMinPairingHeap[int]),
+		"MinMax Heap":  wrap(NewOrderedMinMaxHeap[int]),
+		"Stable Heap":  wrap(NewStableMin[int]),
 	}
 
 	for name, fn := range heaps {
@@ -186,3 +196,229 @@ func BenchmarkHeaps(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkHeapRandom(b *testing.B) {
+	const size = 1 << 16
+	heaps := map[string]func() PriorityQueue[int]{
+		"Heap":      wrap(NewMinHeap[int]),
+		"Func Heap": wrap(NewMinFuncHeap[int]),
+	}
+
+	vals := make([]int, size)
+	for idx := range vals {
+		vals[idx] = rand.Int()
+	}
+
+	for name, fn := range heaps {
+		b.Run(name, func(b *testing.B) {
+			for range b.N {
+				heap := fn()
+				for _, v := range vals {
+					heap.Insert(v)
+				}
+				for range size {
+					heap.Pop()
+				}
+			}
+		})
+	}
+}
+
+type bulkHeap interface {
+	PriorityQueue[int]
+	Peek() (int, bool)
+	PushPop(int) int
+	Replace(int) (int, bool)
+	Clear()
+	Drain() iter.Seq[int]
+}
+
+func TestHeapBulkOperations(t *testing.T) {
+	heaps := map[string]func(vals []int) bulkHeap{
+		"Heap":      func(vals []int) bulkHeap { return NewMinHeapFrom(vals) },
+		"Func Heap": func(vals []int) bulkHeap { return NewHeapFrom(intLess, vals) },
+	}
+	tests := map[string]func(t *testing.T, newHeap func(vals []int) bulkHeap){
+		"Heapify": func(t *testing.T, newHeap func(vals []int) bulkHeap) {
+			vals := make([]int, 1000)
+			for idx := range vals {
+				vals[idx] = rand.IntN(5000)
+			}
+			original := slices.Clone(vals)
+
+			h := newHeap(vals)
+			assert.Equal(t, original, vals, "input slice should not be modified")
+			assert.Equal(t, len(vals), h.Len())
+
+			slices.Sort(vals)
+			assert.Equal(t, vals, slices.Collect(h.Drain()))
+			assert.Equal(t, 0, h.Len())
+		},
+		"Heapify empty": func(t *testing.T, newHeap func(vals []int) bulkHeap) {
+			h := newHeap(nil)
+			assert.Equal(t, 0, h.Len())
+			_, ok := h.Peek()
+			assert.False(t, ok)
+			assert.Empty(t, slices.Collect(h.Drain()))
+
+			h.Insert(1)
+			v, ok := h.Pop()
+			assert.True(t, ok)
+			assert.Equal(t, 1, v)
+		},
+		"Peek": func(t *testing.T, newHeap func(vals []int) bulkHeap) {
+			h := newHeap([]int{5, 2, 8})
+			v, ok := h.Peek()
+			assert.True(t, ok)
+			assert.Equal(t, 2, v)
+			assert.Equal(t, 3, h.Len())
+		},
+		"PushPop": func(t *testing.T, newHeap func(vals []int) bulkHeap) {
+			h := newHeap([]int{5, 2, 8})
+			assert.Equal(t, 1, h.PushPop(1))
+			assert.Equal(t, 2, h.PushPop(6))
+			assert.Equal(t, []int{5, 6, 8}, slices.Collect(h.Drain()))
+			assert.Equal(t, 3, h.PushPop(3))
+		},
+		"Replace": func(t *testing.T, newHeap func(vals []int) bulkHeap) {
+			h := newHeap(nil)
+			_, ok := h.Replace(4)
+			assert.False(t, ok)
+
+			v, ok := h.Replace(1)
+			assert.True(t, ok)
+			assert.Equal(t, 4, v)
+
+			v, ok = h.Replace(7)
+			assert.True(t, ok)
+			assert.Equal(t, 1, v)
+			assert.Equal(t, []int{7}, slices.Collect(h.Drain()))
+		},
+		"Clear": func(t *testing.T, newHeap func(vals []int) bulkHeap) {
+			h := newHeap([]int{5, 2, 8})
+			h.Clear()
+			assert.Equal(t, 0, h.Len())
+			_, ok := h.Pop()
+			assert.False(t, ok)
+		},
+	}
+
+	for name, fn := range heaps {
+		t.Run(name, func(t *testing.T) {
+			for name, tc := range tests {
+				t.Run(name, func(t *testing.T) {
+					tc(t, fn)
+				})
+			}
+		})
+	}
+}
+
+func BenchmarkHeapify(b *testing.B) {
+	vals := make([]int, 1<<16)
+	for idx := range vals {
+		vals[idx] = rand.Int()
+	}
+
+	b.Run("NewMinHeapFrom", func(b *testing.B) {
+		for range b.N {
+			_ = NewMinHeapFrom(vals)
+		}
+	})
+
+	b.Run("Insert", func(b *testing.B) {
+		for range b.N {
+			h := NewMinHeap[int]()
+			for _, v := range vals {
+				h.Insert(v)
+			}
+		}
+	})
+}
+
+type iterHeap interface {
+	PriorityQueue[int]
+	All() iter.Seq[int]
+	Drain() iter.Seq[int]
+}
+
+// indexedIterHeap discards the handles returned by IndexedHeap.Insert so that it satisfies iterHeap.
+type indexedIterHeap struct {
+	*IndexedHeap[int]
+}
+
+func (h indexedIterHeap) Insert(val int) {
+	h.IndexedHeap.Insert(val)
+}
+
+func TestHeapIterators(t *testing.T) {
+	heaps := map[string]func() iterHeap{
+		"Heap":         func() iterHeap { return NewMinHeap[int]() },
+		"Func Heap":    func() iterHeap { return NewMinFuncHeap[int]() },
+		"Pairing Heap": func() iterHeap { return NewMinPairingHeap[int]() },
+		"MinMax Heap":  func() iterHeap { return NewOrderedMinMaxHeap[int]() },
+		"Stable Heap":  func() iterHeap { return NewStableMin[int]() },
+		"4-ary Heap":   func() iterHeap { return NewDaryMin[int](4) },
+		"Indexed Heap": func() iterHeap { return indexedIterHeap{NewIndexedHeap(intLess)} },
+	}
+	tests := map[string]func(t *testing.T, h iterHeap){
+		"All is non destructive": func(t *testing.T, h iterHeap) {
+			expected := make([]int, 100)
+			for idx := range expected {
+				expected[idx] = rand.IntN(50)
+				h.Insert(expected[idx])
+			}
+			slices.Sort(expected)
+
+			assert.Equal(t, expected, slices.Collect(h.All()))
+			assert.Equal(t, expected, slices.Collect(h.All()))
+			assert.Equal(t, len(expected), h.Len())
+		},
+		"All stops early": func(t *testing.T, h iterHeap) {
+			for _, v := range []int{5, 3, 1, 4, 2} {
+				h.Insert(v)
+			}
+
+			result := make([]int, 0)
+			for v := range h.All() {
+				if v > 2 {
+					break
+				}
+				result = append(result, v)
+			}
+			assert.Equal(t, []int{1, 2}, result)
+			assert.Equal(t, 5, h.Len())
+		},
+		"Drain empties the heap": func(t *testing.T, h iterHeap) {
+			for _, v := range []int{5, 3, 1, 4, 2} {
+				h.Insert(v)
+			}
+
+			assert.Equal(t, []int{1, 2, 3, 4, 5}, slices.Collect(h.Drain()))
+			assert.Equal(t, 0, h.Len())
+			assert.Empty(t, slices.Collect(h.All()))
+		},
+		"Drain stops early": func(t *testing.T, h iterHeap) {
+			for _, v := range []int{5, 3, 1, 4, 2} {
+				h.Insert(v)
+			}
+
+			for v := range h.Drain() {
+				if v == 2 {
+					break
+				}
+			}
+			assert.Equal(t, []int{3, 4, 5}, slices.Collect(h.All()))
+		},
+	}
+
+	for name, fn := range heaps {
+		t.Run(name, func(t *testing.T) {
+			for name, tc := range tests {
+				t.Run(name, func(t *testing.T) {
+					tc(t, fn())
+				})
+			}
+		})
+	}
+}
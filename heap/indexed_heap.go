@@ -0,0 +1,158 @@
+package heap
+
+import "iter"
+
+// Handle refers to an element inserted into an IndexedHeap.
+// It stays valid until the element is popped or removed.
+type Handle[T any] struct {
+	item *indexedItem[T]
+}
+
+type indexedItem[T any] struct {
+	val   T
+	index int
+	owner *IndexedHeap[T]
+}
+
+// IndexedHeap is a binary heap that hands out a Handle for every inserted element,
+// allowing the element to be updated or removed in O(log n).
+type IndexedHeap[T any] struct {
+	buffer []*indexedItem[T]
+	lessFn LessFn[T]
+}
+
+// NewIndexedHeap creates an empty indexed heap ordered by less.
+func NewIndexedHeap[T any](less LessFn[T]) *IndexedHeap[T] {
+	return &IndexedHeap[T]{buffer: make([]*indexedItem[T], 0), lessFn: less}
+}
+
+// Insert adds val to the heap and returns a handle to it.
+func (h *IndexedHeap[T]) Insert(val T) Handle[T] {
+	item := &indexedItem[T]{val: val, index: len(h.buffer), owner: h}
+	h.buffer = append(h.buffer, item)
+	siftUp(h, item.index)
+
+	return Handle[T]{item: item}
+}
+
+// Pop removes and returns the first element of the heap.
+func (h *IndexedHeap[T]) Pop() (T, bool) {
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.removeAt(0), true
+}
+
+// Peek returns the first element of the heap without removing it.
+func (h *IndexedHeap[T]) Peek() (T, bool) {
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.buffer[0].val, true
+}
+
+// Get returns the value referred to by handle.
+// Returns false if the handle is no longer in the heap.
+func (h *IndexedHeap[T]) Get(handle Handle[T]) (T, bool) {
+	if !h.Contains(handle) {
+		var empty T
+		return empty, false
+	}
+
+	return handle.item.val, true
+}
+
+// Update replaces the value referred to by handle and restores the heap property.
+// Returns false if the handle is no longer in the heap.
+func (h *IndexedHeap[T]) Update(handle Handle[T], val T) bool {
+	if !h.Contains(handle) {
+		return false
+	}
+
+	handle.item.val = val
+	h.fix(handle.item.index)
+	return true
+}
+
+// Remove deletes the value referred to by handle and returns it.
+// Returns false if the handle is no longer in the heap.
+func (h *IndexedHeap[T]) Remove(handle Handle[T]) (T, bool) {
+	if !h.Contains(handle) {
+		var empty T
+		return empty, false
+	}
+
+	return h.removeAt(handle.item.index), true
+}
+
+// Contains reports whether handle still refers to an element in the heap.
+func (h *IndexedHeap[T]) Contains(handle Handle[T]) bool {
+	return handle.item != nil && handle.item.owner == h
+}
+
+// All returns an iterator over the elements in pop order without removing them.
+// The heap must not be modified while iterating.
+func (h *IndexedHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for idx := range heapOrder(len(h.buffer), 2, h.less) {
+			if !yield(h.buffer[idx].val) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops the elements in pop order, invalidating their handles.
+// Stopping the iteration early leaves the remaining elements in the heap.
+func (h *IndexedHeap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := h.Pop()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (h *IndexedHeap[T]) Len() int {
+	return len(h.buffer)
+}
+
+func (h *IndexedHeap[T]) removeAt(idx int) T {
+	last := len(h.buffer) - 1
+	item := h.buffer[idx]
+	if idx != last {
+		h.swap(idx, last)
+	}
+
+	h.buffer[last] = nil
+	h.buffer = h.buffer[:last]
+	if idx != last {
+		h.fix(idx)
+	}
+
+	item.owner = nil
+	item.index = -1
+	return item.val
+}
+
+func (h *IndexedHeap[T]) fix(idx int) {
+	if !siftDown(h, idx, len(h.buffer)) {
+		siftUp(h, idx)
+	}
+}
+
+func (h *IndexedHeap[T]) less(i, j int) bool {
+	return h.lessFn(h.buffer[i].val, h.buffer[j].val)
+}
+
+func (h *IndexedHeap[T]) swap(i, j int) {
+	h.buffer[i], h.buffer[j] = h.buffer[j], h.buffer[i]
+	h.buffer[i].index = i
+	h.buffer[j].index = j
+}
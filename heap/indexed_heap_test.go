@@ -0,0 +1,138 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+func TestIndexedHeap(t *testing.T) {
+	tests := map[string]func(t *testing.T, h *IndexedHeap[int]){
+		"Pop in order": func(t *testing.T, h *IndexedHeap[int]) {
+			for _, v := range []int{5, 3, 8, 1, 9} {
+				h.Insert(v)
+			}
+
+			for _, expected := range []int{1, 3, 5, 8, 9} {
+				v, ok := h.Pop()
+				assert.True(t, ok)
+				assert.Equal(t, expected, v)
+			}
+
+			_, ok := h.Pop()
+			assert.False(t, ok)
+		},
+		"Drain invalidates handles": func(t *testing.T, h *IndexedHeap[int]) {
+			handles := make([]Handle[int], 0)
+			for _, v := range []int{5, 3, 8} {
+				handles = append(handles, h.Insert(v))
+			}
+
+			assert.Equal(t, []int{3, 5, 8}, slices.Collect(h.All()))
+			assert.Equal(t, []int{3, 5, 8}, slices.Collect(h.Drain()))
+			for _, handle := range handles {
+				assert.False(t, h.Contains(handle))
+			}
+		},
+		"Decrease key": func(t *testing.T, h *IndexedHeap[int]) {
+			h.Insert(5)
+			handle := h.Insert(10)
+			h.Insert(7)
+
+			assert.True(t, h.Update(handle, 1))
+			v, ok := h.Peek()
+			assert.True(t, ok)
+			assert.Equal(t, 1, v)
+		},
+		"Increase key": func(t *testing.T, h *IndexedHeap[int]) {
+			handle := h.Insert(1)
+			h.Insert(5)
+			h.Insert(7)
+
+			assert.True(t, h.Update(handle, 10))
+			for _, expected := range []int{5, 7, 10} {
+				v, _ := h.Pop()
+				assert.Equal(t, expected, v)
+			}
+		},
+		"Remove by handle": func(t *testing.T, h *IndexedHeap[int]) {
+			h.Insert(1)
+			handle := h.Insert(5)
+			h.Insert(7)
+
+			assert.True(t, h.Contains(handle))
+			v, ok := h.Remove(handle)
+			assert.True(t, ok)
+			assert.Equal(t, 5, v)
+			assert.False(t, h.Contains(handle))
+			assert.Equal(t, 2, h.Len())
+
+			_, ok = h.Remove(handle)
+			assert.False(t, ok)
+			assert.False(t, h.Update(handle, 3))
+			_, ok = h.Get(handle)
+			assert.False(t, ok)
+		},
+		"Popped handle is invalid": func(t *testing.T, h *IndexedHeap[int]) {
+			handle := h.Insert(1)
+			_, ok := h.Pop()
+			assert.True(t, ok)
+			assert.False(t, h.Contains(handle))
+		},
+		"Handle from another heap": func(t *testing.T, h *IndexedHeap[int]) {
+			other := NewIndexedHeap(intLess)
+			handle := other.Insert(1)
+			assert.False(t, h.Contains(handle))
+			assert.False(t, h.Contains(Handle[int]{}))
+		},
+		"Random operations": func(t *testing.T, h *IndexedHeap[int]) {
+			handles := make([]Handle[int], 0)
+			values := make(map[Handle[int]]int)
+			for range 1000 {
+				switch op := rand.IntN(3); {
+				case op == 0 || len(handles) == 0:
+					v := rand.IntN(1000)
+					handle := h.Insert(v)
+					handles = append(handles, handle)
+					values[handle] = v
+				case op == 1:
+					handle := handles[rand.IntN(len(handles))]
+					v := rand.IntN(1000)
+					assert.True(t, h.Update(handle, v))
+					values[handle] = v
+				default:
+					idx := rand.IntN(len(handles))
+					handle := handles[idx]
+					v, ok := h.Remove(handle)
+					assert.True(t, ok)
+					assert.Equal(t, values[handle], v)
+					handles = slices.Delete(handles, idx, idx+1)
+					delete(values, handle)
+				}
+			}
+
+			expected := make([]int, 0, len(values))
+			for _, v := range values {
+				expected = append(expected, v)
+			}
+			slices.Sort(expected)
+
+			assert.Equal(t, len(expected), h.Len())
+			for _, e := range expected {
+				v, ok := h.Pop()
+				assert.True(t, ok)
+				assert.Equal(t, e, v)
+			}
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc(t, NewIndexedHeap(intLess))
+		})
+	}
+}
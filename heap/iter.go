@@ -0,0 +1,40 @@
+package heap
+
+import "iter"
+
+// heapOrder yields the indices of a heap with n elements and the given arity in pop order.
+// The heap is left untouched and only the elements visited so far are tracked,
+// so stopping early after k elements costs O(k log k).
+func heapOrder(n, arity int, less func(i, j int) bool) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		if n == 0 {
+			return
+		}
+
+		frontier := NewHeap(less)
+		frontier.Insert(0)
+		for frontier.Len() > 0 {
+			idx, _ := frontier.Pop()
+			if !yield(idx) {
+				return
+			}
+
+			first := idx*arity + 1
+			for child := first; child < min(first+arity, n); child++ {
+				frontier.Insert(child)
+			}
+		}
+	}
+}
+
+// drain yields elements popped from pq until it is empty or the consumer stops.
+func drain[T any](pq PriorityQueue[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := pq.Pop()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package heap
+
+import (
+	"iter"
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+type mergeCursor[T any] struct {
+	val    T
+	next   func() (T, bool)
+	source int
+}
+
+// Merge combines sorted sources into a single ascending sequence.
+func Merge[T constraints.Ordered](sources ...iter.Seq[T]) iter.Seq[T] {
+	return MergeFunc(func(a, b T) bool { return a < b }, sources...)
+}
+
+// MergeSlices combines sorted slices into a single ascending sequence.
+func MergeSlices[T constraints.Ordered](sources ...[]T) iter.Seq[T] {
+	seqs := make([]iter.Seq[T], 0, len(sources))
+	for _, s := range sources {
+		seqs = append(seqs, slices.Values(s))
+	}
+
+	return Merge(seqs...)
+}
+
+// MergeFunc combines sources sorted by less into a single sequence sorted by less.
+// Equal elements are yielded in the order of the sources they come from.
+// Sources are only advanced as the consumer ranges and are released once it stops.
+func MergeFunc[T any](less LessFn[T], sources ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		cursors := NewHeap(func(a, b *mergeCursor[T]) bool {
+			if less(a.val, b.val) {
+				return true
+			}
+			if less(b.val, a.val) {
+				return false
+			}
+			return a.source < b.source
+		})
+
+		for idx, source := range sources {
+			next, stop := iter.Pull(source)
+			defer stop()
+
+			if v, ok := next(); ok {
+				cursors.Insert(&mergeCursor[T]{val: v, next: next, source: idx})
+			}
+		}
+
+		for {
+			cursor, ok := cursors.Peek()
+			if !ok {
+				return
+			}
+
+			if !yield(cursor.val) {
+				return
+			}
+
+			if v, ok := cursor.next(); ok {
+				cursor.val = v
+				siftDown(cursors, 0, cursors.Len())
+				continue
+			}
+
+			cursors.Pop()
+		}
+	}
+}
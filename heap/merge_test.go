@@ -0,0 +1,118 @@
+package heap
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	tests := map[string]struct {
+		sources  [][]int
+		expected []int
+	}{
+		"no sources": {
+			sources:  nil,
+			expected: nil,
+		},
+		"empty sources": {
+			sources:  [][]int{{}, {}},
+			expected: nil,
+		},
+		"single source": {
+			sources:  [][]int{{1, 2, 3}},
+			expected: []int{1, 2, 3},
+		},
+		"interleaved sources": {
+			sources:  [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}},
+			expected: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		"uneven sources with duplicates": {
+			sources:  [][]int{{1, 1, 10}, {}, {1, 2}, {0}},
+			expected: []int{0, 1, 1, 1, 2, 10},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, slices.Collect(MergeSlices(tc.sources...)))
+		})
+	}
+}
+
+func TestMerge_Random(t *testing.T) {
+	sources := make([]iter.Seq[int], 0, 20)
+	expected := make([]int, 0)
+	for range 20 {
+		s := make([]int, rand.IntN(100))
+		for idx := range s {
+			s[idx] = rand.IntN(1000)
+		}
+		slices.Sort(s)
+
+		expected = append(expected, s...)
+		sources = append(sources, slices.Values(s))
+	}
+	slices.Sort(expected)
+
+	assert.Equal(t, expected, slices.Collect(Merge(sources...)))
+}
+
+func TestMergeFunc_Stable(t *testing.T) {
+	byPriority := func(a, b job) bool { return a.priority < b.priority }
+	result := slices.Collect(MergeFunc(
+		byPriority,
+		slices.Values([]job{{"a1", 1}, {"a2", 2}}),
+		slices.Values([]job{{"b1", 1}, {"b2", 2}}),
+	))
+
+	names := make([]string, 0, len(result))
+	for _, j := range result {
+		names = append(names, j.name)
+	}
+	assert.Equal(t, []string{"a1", "b1", "a2", "b2"}, names)
+}
+
+func TestMerge_EarlyTermination(t *testing.T) {
+	stopped := 0
+	source := func(vals ...int) iter.Seq[int] {
+		return func(yield func(int) bool) {
+			defer func() { stopped++ }()
+			for _, v := range vals {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+
+	result := make([]int, 0)
+	for v := range Merge(source(1, 3, 5), source(2, 4, 6)) {
+		result = append(result, v)
+		if v == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, result)
+	assert.Equal(t, 2, stopped, "all sources should be stopped")
+}
+
+func BenchmarkMerge(b *testing.B) {
+	sources := make([][]int, 32)
+	for idx := range sources {
+		sources[idx] = make([]int, 1024)
+		for j := range sources[idx] {
+			sources[idx][j] = j*len(sources) + idx
+		}
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		for range MergeSlices(sources...) {
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package heap
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	_ PriorityQueue[int] = (*MinMaxHeap[int])(nil)
+)
+
+// End selects one end of a MinMaxHeap.
+type End int
+
+const (
+	// EvictMin evicts the smallest element when a bounded MinMaxHeap is full.
+	EvictMin End = iota
+	// EvictMax evicts the greatest element when a bounded MinMaxHeap is full.
+	EvictMax
+)
+
+// MinMaxHeap is a double ended priority queue ordered by less.
+// Both the smallest and the greatest element can be inspected in O(1) and removed in O(log n).
+//
+// Elements on even levels are no greater than their descendants,
+// while elements on odd levels are no smaller than their descendants.
+type MinMaxHeap[T any] struct {
+	buffer   []T
+	lessFn   LessFn[T]
+	capacity int
+	bounded  bool
+	evict    End
+}
+
+// NewMinMaxHeap creates an empty unbounded min-max heap ordered by less.
+func NewMinMaxHeap[T any](less LessFn[T]) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{buffer: make([]T, 0), lessFn: less}
+}
+
+// NewOrderedMinMaxHeap creates an empty unbounded min-max heap using the natural ordering of T.
+func NewOrderedMinMaxHeap[T constraints.Ordered]() *MinMaxHeap[T] {
+	return NewMinMaxHeap(func(a, b T) bool { return a < b })
+}
+
+// NewBoundedMinMaxHeap creates a min-max heap holding at most capacity elements.
+// Once full, inserting an element evicts the element at the evict end,
+// which may be the inserted element itself.
+func NewBoundedMinMaxHeap[T any](less LessFn[T], capacity int, evict End) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{
+		buffer:   make([]T, 0, max(capacity, 0)),
+		lessFn:   less,
+		capacity: max(capacity, 0),
+		bounded:  true,
+		evict:    evict,
+	}
+}
+
+// Insert adds val to the heap, evicting an element if the heap is bounded and full.
+func (h *MinMaxHeap[T]) Insert(val T) {
+	h.Offer(val)
+}
+
+// Offer adds val to the heap.
+// If the heap is bounded and full, the evicted element is returned along with true.
+func (h *MinMaxHeap[T]) Offer(val T) (T, bool) {
+	if !h.bounded || len(h.buffer) < h.capacity {
+		h.push(val)
+		var empty T
+		return empty, false
+	}
+
+	if len(h.buffer) == 0 {
+		return val, true
+	}
+
+	switch h.evict {
+	case EvictMax:
+		maxIdx := h.maxIdx()
+		if !h.lessFn(val, h.buffer[maxIdx]) {
+			return val, true
+		}
+
+		evicted := h.removeAt(maxIdx)
+		h.push(val)
+		return evicted, true
+	default:
+		if !h.lessFn(h.buffer[0], val) {
+			return val, true
+		}
+
+		evicted := h.removeAt(0)
+		h.push(val)
+		return evicted, true
+	}
+}
+
+// Pop removes and returns the smallest element.
+func (h *MinMaxHeap[T]) Pop() (T, bool) {
+	return h.PopMin()
+}
+
+// PopMin removes and returns the smallest element.
+func (h *MinMaxHeap[T]) PopMin() (T, bool) {
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.removeAt(0), true
+}
+
+// PopMax removes and returns the greatest element.
+func (h *MinMaxHeap[T]) PopMax() (T, bool) {
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.removeAt(h.maxIdx()), true
+}
+
+// PeekMin returns the smallest element without removing it.
+func (h *MinMaxHeap[T]) PeekMin() (T, bool) {
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.buffer[0], true
+}
+
+// PeekMax returns the greatest element without removing it.
+func (h *MinMaxHeap[T]) PeekMax() (T, bool) {
+	if len(h.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return h.buffer[h.maxIdx()], true
+}
+
+// All returns an iterator over the elements from smallest to greatest without removing them.
+// The heap must not be modified while iterating.
+func (h *MinMaxHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		clone := &MinMaxHeap[T]{buffer: slices.Clone(h.buffer), lessFn: h.lessFn}
+		for v := range clone.Drain() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops the elements from smallest to greatest.
+// Stopping the iteration early leaves the remaining elements in the heap.
+func (h *MinMaxHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+func (h *MinMaxHeap[T]) Len() int {
+	return len(h.buffer)
+}
+
+func (h *MinMaxHeap[T]) push(val T) {
+	h.buffer = append(h.buffer, val)
+	h.bubbleUp(len(h.buffer) - 1)
+}
+
+// maxIdx returns the index of the greatest element of a non-empty heap.
+func (h *MinMaxHeap[T]) maxIdx() int {
+	switch len(h.buffer) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+
+	if h.lessFn(h.buffer[1], h.buffer[2]) {
+		return 2
+	}
+	return 1
+}
+
+func (h *MinMaxHeap[T]) removeAt(idx int) T {
+	result := h.buffer[idx]
+	last := len(h.buffer) - 1
+	h.buffer[idx] = h.buffer[last]
+
+	var empty T
+	h.buffer[last] = empty
+	h.buffer = h.buffer[:last]
+
+	if idx < last {
+		h.trickleDown(idx)
+	}
+
+	return result
+}
+
+// before reports whether the element at i belongs closer to the root than the element at j
+// on a level of the given kind.
+func (h *MinMaxHeap[T]) before(i, j int, maxLevel bool) bool {
+	if maxLevel {
+		return h.lessFn(h.buffer[j], h.buffer[i])
+	}
+	return h.lessFn(h.buffer[i], h.buffer[j])
+}
+
+func (h *MinMaxHeap[T]) swap(i, j int) {
+	h.buffer[i], h.buffer[j] = h.buffer[j], h.buffer[i]
+}
+
+func (h *MinMaxHeap[T]) bubbleUp(idx int) {
+	if idx == 0 {
+		return
+	}
+
+	maxLevel := isMaxLevel(idx)
+	parentIdx := parent(idx)
+	if h.before(idx, parentIdx, !maxLevel) {
+		h.swap(idx, parentIdx)
+		h.bubbleUpGrandparent(parentIdx, !maxLevel)
+		return
+	}
+
+	h.bubbleUpGrandparent(idx, maxLevel)
+}
+
+func (h *MinMaxHeap[T]) bubbleUpGrandparent(idx int, maxLevel bool) {
+	for idx > 2 {
+		grandparentIdx := parent(parent(idx))
+		if !h.before(idx, grandparentIdx, maxLevel) {
+			return
+		}
+
+		h.swap(idx, grandparentIdx)
+		idx = grandparentIdx
+	}
+}
+
+func (h *MinMaxHeap[T]) trickleDown(idx int) {
+	maxLevel := isMaxLevel(idx)
+	n := len(h.buffer)
+
+	for left(idx) < n {
+		// Find the best element among the children and grandchildren.
+		best := left(idx)
+		isGrandchild := false
+		for _, child := range [2]int{left(idx), right(idx)} {
+			if child >= n {
+				continue
+			}
+			if h.before(child, best, maxLevel) {
+				best, isGrandchild = child, false
+			}
+
+			for _, grandchild := range [2]int{left(child), right(child)} {
+				if grandchild < n && h.before(grandchild, best, maxLevel) {
+					best, isGrandchild = grandchild, true
+				}
+			}
+		}
+
+		if !h.before(best, idx, maxLevel) {
+			return
+		}
+
+		h.swap(best, idx)
+		if !isGrandchild {
+			return
+		}
+
+		if parentIdx := parent(best); h.before(parentIdx, best, maxLevel) {
+			h.swap(best, parentIdx)
+		}
+		idx = best
+	}
+}
+
+func isMaxLevel(idx int) bool {
+	return bits.Len(uint(idx+1))%2 == 0
+}
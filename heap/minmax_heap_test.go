@@ -0,0 +1,150 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinMaxHeap(t *testing.T) {
+	tests := map[string]func(t *testing.T, h *MinMaxHeap[int]){
+		"Empty": func(t *testing.T, h *MinMaxHeap[int]) {
+			_, ok := h.PeekMin()
+			assert.False(t, ok)
+			_, ok = h.PeekMax()
+			assert.False(t, ok)
+			_, ok = h.PopMin()
+			assert.False(t, ok)
+			_, ok = h.PopMax()
+			assert.False(t, ok)
+		},
+		"Peek both ends": func(t *testing.T, h *MinMaxHeap[int]) {
+			for _, v := range []int{5, 3, 8, 1, 9, 2} {
+				h.Insert(v)
+			}
+
+			v, ok := h.PeekMin()
+			assert.True(t, ok)
+			assert.Equal(t, 1, v)
+
+			v, ok = h.PeekMax()
+			assert.True(t, ok)
+			assert.Equal(t, 9, v)
+			assert.Equal(t, 6, h.Len())
+		},
+		"Pop alternating ends": func(t *testing.T, h *MinMaxHeap[int]) {
+			for _, v := range []int{5, 3, 8, 1, 9, 2, 7} {
+				h.Insert(v)
+			}
+
+			for _, expected := range [][2]int{{1, 9}, {2, 8}, {3, 7}} {
+				v, ok := h.PopMin()
+				assert.True(t, ok)
+				assert.Equal(t, expected[0], v)
+
+				v, ok = h.PopMax()
+				assert.True(t, ok)
+				assert.Equal(t, expected[1], v)
+			}
+
+			v, ok := h.PopMax()
+			assert.True(t, ok)
+			assert.Equal(t, 5, v)
+			assert.Equal(t, 0, h.Len())
+		},
+		"Random against sorted slice": func(t *testing.T, h *MinMaxHeap[int]) {
+			expected := make([]int, 0)
+			for range 5000 {
+				switch rand.IntN(3) {
+				case 0:
+					v, ok := h.PopMin()
+					if len(expected) == 0 {
+						assert.False(t, ok)
+						continue
+					}
+					assert.True(t, ok)
+					assert.Equal(t, expected[0], v)
+					expected = expected[1:]
+				case 1:
+					v, ok := h.PopMax()
+					if len(expected) == 0 {
+						assert.False(t, ok)
+						continue
+					}
+					assert.True(t, ok)
+					assert.Equal(t, expected[len(expected)-1], v)
+					expected = expected[:len(expected)-1]
+				default:
+					for range 2 {
+						v := rand.IntN(1000)
+						h.Insert(v)
+						expected = append(expected, v)
+					}
+					slices.Sort(expected)
+				}
+				assert.Equal(t, len(expected), h.Len())
+			}
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc(t, NewOrderedMinMaxHeap[int]())
+		})
+	}
+}
+
+func TestMinMaxHeap_Bounded(t *testing.T) {
+	tests := map[string]struct {
+		capacity int
+		evict    End
+		input    []int
+		evicted  []int
+		expected []int
+	}{
+		"evict min keeps greatest": {
+			capacity: 3,
+			evict:    EvictMin,
+			input:    []int{5, 1, 8, 3, 9, 2},
+			evicted:  []int{1, 3, 2},
+			expected: []int{5, 8, 9},
+		},
+		"evict max keeps smallest": {
+			capacity: 3,
+			evict:    EvictMax,
+			input:    []int{5, 1, 8, 3, 9, 2},
+			evicted:  []int{8, 9, 5},
+			expected: []int{1, 2, 3},
+		},
+		"zero capacity rejects everything": {
+			capacity: 0,
+			evict:    EvictMin,
+			input:    []int{1, 2},
+			evicted:  []int{1, 2},
+			expected: []int{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := NewBoundedMinMaxHeap(intLess, tc.capacity, tc.evict)
+			evicted := make([]int, 0)
+			for _, v := range tc.input {
+				if e, ok := h.Offer(v); ok {
+					evicted = append(evicted, e)
+				}
+				assert.LessOrEqual(t, h.Len(), tc.capacity)
+			}
+			assert.Equal(t, tc.evicted, evicted)
+
+			result := make([]int, 0)
+			for h.Len() > 0 {
+				v, _ := h.Pop()
+				result = append(result, v)
+			}
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
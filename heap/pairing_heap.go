@@ -0,0 +1,160 @@
+package heap
+
+import (
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	_ PriorityQueue[int] = (*PairingHeap[int])(nil)
+)
+
+type pairingNode[T any] struct {
+	val     T
+	child   *pairingNode[T]
+	sibling *pairingNode[T]
+}
+
+// PairingHeap is a pointer based heap ordered by less that supports melding two heaps in O(1).
+// Insert and Meld are O(1) while Pop is amortized O(log n).
+type PairingHeap[T any] struct {
+	root   *pairingNode[T]
+	lessFn LessFn[T]
+	size   int
+}
+
+// NewPairingHeap creates an empty pairing heap ordered by less.
+func NewPairingHeap[T any](less LessFn[T]) *PairingHeap[T] {
+	return &PairingHeap[T]{lessFn: less}
+}
+
+// NewMinPairingHeap creates an empty pairing heap that pops the smallest element first.
+func NewMinPairingHeap[T constraints.Ordered]() *PairingHeap[T] {
+	return NewPairingHeap(func(a, b T) bool { return a < b })
+}
+
+func (h *PairingHeap[T]) Insert(val T) {
+	h.root = h.link(h.root, &pairingNode[T]{val: val})
+	h.size++
+}
+
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var empty T
+		return empty, false
+	}
+
+	result := h.root.val
+	h.root = h.mergePairs(h.root.child)
+	h.size--
+	return result, true
+}
+
+// Peek returns the first element without removing it.
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var empty T
+		return empty, false
+	}
+
+	return h.root.val, true
+}
+
+// Meld moves every element of other into h in O(1), leaving other empty.
+// Both heaps are expected to be ordered by the same comparator.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == h {
+		return
+	}
+
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+
+	other.root = nil
+	other.size = 0
+}
+
+// All returns an iterator over the elements in pop order without removing them.
+// The heap must not be modified while iterating.
+func (h *PairingHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if h.root == nil {
+			return
+		}
+
+		frontier := NewHeap(func(a, b *pairingNode[T]) bool { return h.lessFn(a.val, b.val) })
+		frontier.Insert(h.root)
+		for frontier.Len() > 0 {
+			node, _ := frontier.Pop()
+			if !yield(node.val) {
+				return
+			}
+
+			for child := node.child; child != nil; child = child.sibling {
+				frontier.Insert(child)
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops the elements in pop order.
+// Stopping the iteration early leaves the remaining elements in the heap.
+func (h *PairingHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+func (h *PairingHeap[T]) Len() int {
+	return h.size
+}
+
+// link makes the root with the larger value the first child of the other and returns the new root.
+func (h *PairingHeap[T]) link(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if h.lessFn(b.val, a.val) {
+		a, b = b, a
+	}
+
+	b.sibling = a.child
+	a.child = b
+	return a
+}
+
+// mergePairs combines a list of siblings using the standard two-pass strategy.
+func (h *PairingHeap[T]) mergePairs(first *pairingNode[T]) *pairingNode[T] {
+	// First pass: link siblings pairwise from left to right, collecting the results in reverse order.
+	var pairs *pairingNode[T]
+	for first != nil {
+		a := first
+		b := a.sibling
+		if b == nil {
+			a.sibling = pairs
+			pairs = a
+			break
+		}
+
+		first = b.sibling
+		a.sibling, b.sibling = nil, nil
+
+		merged := h.link(a, b)
+		merged.sibling = pairs
+		pairs = merged
+	}
+
+	// Second pass: link the pairs from right to left.
+	var root *pairingNode[T]
+	for pairs != nil {
+		next := pairs.sibling
+		pairs.sibling = nil
+		root = h.link(root, pairs)
+		pairs = next
+	}
+
+	return root
+}
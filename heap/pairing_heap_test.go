@@ -0,0 +1,158 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPairingHeap_Meld(t *testing.T) {
+	tests := map[string]struct {
+		left     []int
+		right    []int
+		expected []int
+	}{
+		"both empty": {
+			expected: []int{},
+		},
+		"empty left": {
+			right:    []int{3, 1, 2},
+			expected: []int{1, 2, 3},
+		},
+		"empty right": {
+			left:     []int{3, 1, 2},
+			expected: []int{1, 2, 3},
+		},
+		"interleaved": {
+			left:     []int{9, 1, 5, 7},
+			right:    []int{2, 8, 4, 6, 3},
+			expected: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			left := NewMinPairingHeap[int]()
+			for _, v := range tc.left {
+				left.Insert(v)
+			}
+			right := NewMinPairingHeap[int]()
+			for _, v := range tc.right {
+				right.Insert(v)
+			}
+
+			left.Meld(right)
+			assert.Equal(t, 0, right.Len())
+			assert.Equal(t, len(tc.expected), left.Len())
+
+			result := make([]int, 0, left.Len())
+			for left.Len() > 0 {
+				v, ok := left.Pop()
+				assert.True(t, ok)
+				result = append(result, v)
+			}
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestPairingHeap_Random(t *testing.T) {
+	h := NewPairingHeap(func(a, b int) bool { return a > b })
+	expected := make([]int, 0)
+	for range 100 {
+		other := NewPairingHeap(h.lessFn)
+		for range rand.IntN(50) {
+			v := rand.IntN(1000)
+			other.Insert(v)
+			expected = append(expected, v)
+		}
+		h.Meld(other)
+
+		// Pop a few elements between melds to exercise the two-pass merge.
+		slices.Sort(expected)
+		for range rand.IntN(10) {
+			if len(expected) == 0 {
+				break
+			}
+
+			v, ok := h.Pop()
+			assert.True(t, ok)
+			assert.Equal(t, expected[len(expected)-1], v)
+			expected = expected[:len(expected)-1]
+		}
+	}
+
+	peek, ok := h.Peek()
+	if len(expected) > 0 {
+		assert.True(t, ok)
+		assert.Equal(t, expected[len(expected)-1], peek)
+	}
+	assert.Equal(t, len(expected), h.Len())
+}
+
+func BenchmarkHeapWorkloads(b *testing.B) {
+	heaps := map[string]func() PriorityQueue[int]{
+		"Heap":         wrap(NewMinHeap[int]),
+		"Func Heap":    wrap(NewMinFuncHeap[int]),
+		"Pairing Heap": wrap(NewMinPairingHeap[int]),
+	}
+	workloads := map[string]struct {
+		inserts int
+		pops    int
+	}{
+		"Insert heavy": {inserts: 4, pops: 1},
+		"Pop heavy":    {inserts: 1, pops: 1},
+	}
+
+	for name, fn := range heaps {
+		for workload, tc := range workloads {
+			b.Run(name+"/"+workload, func(b *testing.B) {
+				b.ReportAllocs()
+				h := fn()
+				for range 1 << 12 {
+					h.Insert(rand.Int())
+				}
+
+				b.ResetTimer()
+				for range b.N {
+					for range tc.inserts {
+						h.Insert(rand.Int())
+					}
+					for range tc.pops {
+						h.Pop()
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkMeld(b *testing.B) {
+	const size = 1 << 10
+	b.Run("Pairing Heap", func(b *testing.B) {
+		for range b.N {
+			left, right := NewMinPairingHeap[int](), NewMinPairingHeap[int]()
+			for idx := range size {
+				left.Insert(idx)
+				right.Insert(idx)
+			}
+			left.Meld(right)
+		}
+	})
+
+	b.Run("Heap", func(b *testing.B) {
+		for range b.N {
+			left, right := NewMinHeap[int](), NewMinHeap[int]()
+			for idx := range size {
+				left.Insert(idx)
+				right.Insert(idx)
+			}
+			for right.Len() > 0 {
+				v, _ := right.Pop()
+				left.Insert(v)
+			}
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package heap
+
+import (
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Quantile tracks the q-quantile of a stream of values using two balanced heaps.
+// Add, Remove and Value all run in amortized O(log n).
+//
+// The quantile is computed using the nearest rank method,
+// so the result is always one of the tracked values.
+type Quantile[T constraints.Ordered] struct {
+	q float64
+
+	// low is a max heap holding the smallest values while high is a min heap holding the rest.
+	low  *Heap[T]
+	high *Heap[T]
+
+	// Removed values are deleted lazily once they reach the root of the heap they belong to,
+	// or when the heap is compacted because they outnumber the values still tracked in it.
+	lowRemoved  map[T]int
+	highRemoved map[T]int
+	lowSize     int
+	highSize    int
+
+	counts map[T]int
+}
+
+// NewQuantile creates a tracker for the q-quantile, where q is clamped to [0, 1].
+func NewQuantile[T constraints.Ordered](q float64) *Quantile[T] {
+	return &Quantile[T]{
+		q:           min(max(q, 0), 1),
+		low:         NewMaxFuncHeap[T](),
+		high:        NewMinFuncHeap[T](),
+		lowRemoved:  make(map[T]int),
+		highRemoved: make(map[T]int),
+		counts:      make(map[T]int),
+	}
+}
+
+// Add adds val to the tracked values.
+func (q *Quantile[T]) Add(val T) {
+	q.counts[val]++
+	if top, ok := q.low.Peek(); !ok || val <= top {
+		q.low.Insert(val)
+		q.lowSize++
+	} else {
+		q.high.Insert(val)
+		q.highSize++
+	}
+
+	q.rebalance()
+}
+
+// Remove removes one occurrence of val from the tracked values.
+// Returns false if val is not tracked.
+func (q *Quantile[T]) Remove(val T) bool {
+	if q.counts[val] == 0 {
+		return false
+	}
+
+	if q.counts[val]--; q.counts[val] == 0 {
+		delete(q.counts, val)
+	}
+
+	if top, ok := q.low.Peek(); ok && val <= top {
+		q.lowRemoved[val]++
+		q.lowSize--
+		prune(q.low, q.lowRemoved)
+		compact(q.low, q.lowRemoved, q.lowSize)
+	} else {
+		q.highRemoved[val]++
+		q.highSize--
+		prune(q.high, q.highRemoved)
+		compact(q.high, q.highRemoved, q.highSize)
+	}
+
+	q.rebalance()
+	return true
+}
+
+// Value returns the current quantile.
+// Returns false if no values are tracked.
+func (q *Quantile[T]) Value() (T, bool) {
+	if q.lowSize == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return q.low.Peek()
+}
+
+func (q *Quantile[T]) Len() int {
+	return q.lowSize + q.highSize
+}
+
+// rebalance moves values between the heaps so that low holds exactly the values up to the quantile rank.
+func (q *Quantile[T]) rebalance() {
+	target := q.rank()
+	for q.lowSize > target {
+		v, _ := q.low.Pop()
+		q.high.Insert(v)
+		q.lowSize--
+		q.highSize++
+		prune(q.low, q.lowRemoved)
+	}
+
+	for q.lowSize < target {
+		v, _ := q.high.Pop()
+		q.low.Insert(v)
+		q.highSize--
+		q.lowSize++
+		prune(q.high, q.highRemoved)
+	}
+}
+
+// rank returns the number of values that should be held in low.
+func (q *Quantile[T]) rank() int {
+	n := q.Len()
+	if n == 0 {
+		return 0
+	}
+
+	return min(max(int(math.Ceil(q.q*float64(n))), 1), n)
+}
+
+// prune pops values pending removal from the root of h.
+func prune[T constraints.Ordered](h *Heap[T], removed map[T]int) {
+	for {
+		top, ok := h.Peek()
+		if !ok || removed[top] == 0 {
+			return
+		}
+
+		if removed[top]--; removed[top] == 0 {
+			delete(removed, top)
+		}
+		h.Pop()
+	}
+}
+
+// compact rebuilds h without the values pending removal once they outnumber the live values,
+// so that a sliding window whose oldest values never reach the root does not grow h without bound.
+func compact[T constraints.Ordered](h *Heap[T], removed map[T]int, live int) {
+	if h.Len() <= 2*live {
+		return
+	}
+
+	kept := h.buffer[:0]
+	for _, v := range h.buffer {
+		if removed[v] == 0 {
+			kept = append(kept, v)
+			continue
+		}
+
+		if removed[v]--; removed[v] == 0 {
+			delete(removed, v)
+		}
+	}
+
+	clear(h.buffer[len(kept):])
+	h.buffer = kept
+	heapify(h, len(kept))
+}
+
+// Median tracks the median of a stream of values.
+// For an even number of values, the lower of the two middle values is reported.
+type Median[T constraints.Ordered] struct {
+	*Quantile[T]
+}
+
+// NewMedian creates an empty median tracker.
+func NewMedian[T constraints.Ordered]() *Median[T] {
+	return &Median[T]{Quantile: NewQuantile[T](0.5)}
+}
+
+// Median returns the current median.
+// Returns false if no values are tracked.
+func (m *Median[T]) Median() (T, bool) {
+	return m.Value()
+}
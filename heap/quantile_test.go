@@ -0,0 +1,126 @@
+package heap
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// oracleQuantile computes the nearest rank quantile by sorting.
+func oracleQuantile(vals []int, q float64) int {
+	sorted := slices.Clone(vals)
+	slices.Sort(sorted)
+
+	rank := min(max(int(math.Ceil(q*float64(len(sorted)))), 1), len(sorted))
+	return sorted[rank-1]
+}
+
+func TestMedian(t *testing.T) {
+	m := NewMedian[int]()
+	_, ok := m.Median()
+	assert.False(t, ok)
+
+	for _, tc := range []struct {
+		add      int
+		expected int
+	}{
+		{add: 5, expected: 5},
+		{add: 1, expected: 1},
+		{add: 9, expected: 5},
+		{add: 7, expected: 5},
+		{add: 8, expected: 7},
+	} {
+		m.Add(tc.add)
+		v, ok := m.Median()
+		assert.True(t, ok)
+		assert.Equal(t, tc.expected, v)
+	}
+
+	assert.False(t, m.Remove(100))
+	assert.True(t, m.Remove(7))
+	v, _ := m.Median()
+	assert.Equal(t, 5, v)
+	assert.Equal(t, 4, m.Len())
+}
+
+func TestQuantile_SlidingWindow(t *testing.T) {
+	tests := map[string]struct {
+		q      float64
+		window int
+	}{
+		"median":           {q: 0.5, window: 51},
+		"median even":      {q: 0.5, window: 50},
+		"p90":              {q: 0.9, window: 100},
+		"p99":              {q: 0.99, window: 200},
+		"minimum":          {q: 0, window: 10},
+		"maximum":          {q: 1, window: 10},
+		"single element":   {q: 0.5, window: 1},
+		"clamped quantile": {q: 2, window: 10},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tracker := NewQuantile[int](tc.q)
+			window := make([]int, 0, tc.window)
+			for range 2000 {
+				// A small value range makes duplicates common.
+				v := rand.IntN(50)
+				tracker.Add(v)
+				window = append(window, v)
+
+				if len(window) > tc.window {
+					assert.True(t, tracker.Remove(window[0]))
+					window = window[1:]
+				}
+
+				result, ok := tracker.Value()
+				assert.True(t, ok)
+				assert.Equal(t, oracleQuantile(window, min(tc.q, 1)), result)
+				assert.Equal(t, len(window), tracker.Len())
+			}
+
+			for _, v := range window {
+				assert.True(t, tracker.Remove(v))
+			}
+			_, ok := tracker.Value()
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestQuantile_SlidingWindowMemory(t *testing.T) {
+	const window = 100
+	tests := map[string]func(idx int) int{
+		"increasing": func(idx int) int { return idx },
+		"decreasing": func(idx int) int { return -idx },
+		"random":     func(int) int { return rand.IntN(1000) },
+	}
+
+	for name, next := range tests {
+		t.Run(name, func(t *testing.T) {
+			tracker := NewMedian[int]()
+			values := make([]int, 0, 50_000)
+			for idx := range 50_000 {
+				values = append(values, next(idx))
+				tracker.Add(values[idx])
+				if idx >= window {
+					assert.True(t, tracker.Remove(values[idx-window]))
+				}
+
+				// Each heap holds at most as many stale values as live ones, plus the one just removed.
+				if size := tracker.low.Len() + tracker.high.Len(); size > 2*window+2 {
+					t.Fatalf("heaps hold %d values for a window of %d", size, window)
+				}
+				if pending := len(tracker.lowRemoved) + len(tracker.highRemoved); pending > window+2 {
+					t.Fatalf("%d values pending removal for a window of %d", pending, window)
+				}
+			}
+
+			result, _ := tracker.Median()
+			assert.Equal(t, oracleQuantile(values[len(values)-window:], 0.5), result)
+		})
+	}
+}
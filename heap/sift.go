@@ -0,0 +1,64 @@
+package heap
+
+// heapData is the view of a binary heap's backing storage used by the sift helpers.
+type heapData interface {
+	less(i, j int) bool
+	swap(i, j int)
+}
+
+// siftUp moves the element at idx towards the root until its parent is not greater.
+func siftUp(h heapData, idx int) {
+	for idx > 0 {
+		parentIdx := parent(idx)
+		if !h.less(idx, parentIdx) {
+			break
+		}
+		h.swap(idx, parentIdx)
+		idx = parentIdx
+	}
+}
+
+// siftDown moves the element at idx towards the leaves of a heap of size n.
+// Returns true if the element was moved.
+func siftDown(h heapData, idx, n int) bool {
+	start := idx
+	for {
+		lIdx := left(idx)
+		if lIdx >= n {
+			break
+		}
+
+		smallest := lIdx
+		if rIdx := right(idx); rIdx < n && h.less(rIdx, lIdx) {
+			smallest = rIdx
+		}
+
+		if !h.less(smallest, idx) {
+			break
+		}
+
+		h.swap(idx, smallest)
+		idx = smallest
+	}
+
+	return idx > start
+}
+
+// heapify arranges the first n elements into a heap in O(n).
+func heapify(h heapData, n int) {
+	for idx := n/2 - 1; idx >= 0; idx-- {
+		siftDown(h, idx, n)
+	}
+}
+
+func left(idx int) int {
+	return idx*2 + 1
+}
+
+func right(idx int) int {
+	return idx*2 + 2
+}
+
+func parent(idx int) int {
+	return (idx - 1) / 2
+}
@@ -0,0 +1,80 @@
+package heap
+
+import (
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	_ PriorityQueue[int] = (*StableHeap[int])(nil)
+)
+
+type stableItem[T any] struct {
+	val T
+	seq uint64
+}
+
+// StableHeap is a heap ordered by less that pops elements of equal priority in insertion order.
+type StableHeap[T any] struct {
+	heap *Heap[stableItem[T]]
+	seq  uint64
+}
+
+// NewStableHeap creates an empty stable heap ordered by less.
+func NewStableHeap[T any](less LessFn[T]) *StableHeap[T] {
+	return &StableHeap[T]{
+		heap: NewHeap(func(a, b stableItem[T]) bool {
+			if less(a.val, b.val) {
+				return true
+			}
+			if less(b.val, a.val) {
+				return false
+			}
+			return a.seq < b.seq
+		}),
+	}
+}
+
+// NewStableMin creates an empty stable heap that pops the smallest element first.
+func NewStableMin[T constraints.Ordered]() *StableHeap[T] {
+	return NewStableHeap(func(a, b T) bool { return a < b })
+}
+
+func (h *StableHeap[T]) Insert(val T) {
+	h.heap.Insert(stableItem[T]{val: val, seq: h.seq})
+	h.seq++
+}
+
+func (h *StableHeap[T]) Pop() (T, bool) {
+	item, ok := h.heap.Pop()
+	return item.val, ok
+}
+
+// Peek returns the first element without removing it.
+func (h *StableHeap[T]) Peek() (T, bool) {
+	item, ok := h.heap.Peek()
+	return item.val, ok
+}
+
+// All returns an iterator over the elements in pop order without removing them.
+// The heap must not be modified while iterating.
+func (h *StableHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range h.heap.All() {
+			if !yield(item.val) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops the elements in pop order.
+// Stopping the iteration early leaves the remaining elements in the heap.
+func (h *StableHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+func (h *StableHeap[T]) Len() int {
+	return h.heap.Len()
+}
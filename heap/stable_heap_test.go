@@ -0,0 +1,74 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type task struct {
+	priority int
+	seq      int
+}
+
+func TestStableHeap_FIFO(t *testing.T) {
+	h := NewStableHeap(func(a, b job) bool { return a.priority < b.priority })
+	for _, j := range []job{{"a", 2}, {"b", 1}, {"c", 2}, {"d", 1}, {"e", 2}} {
+		h.Insert(j)
+	}
+
+	v, ok := h.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, "b", v.name)
+
+	for _, expected := range []string{"b", "d", "a", "c", "e"} {
+		v, ok := h.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, expected, v.name)
+	}
+
+	_, ok = h.Pop()
+	assert.False(t, ok)
+}
+
+func TestStableHeap_Interleaved(t *testing.T) {
+	ops := 2_000_000
+	if testing.Short() {
+		ops = 100_000
+	}
+
+	h := NewStableHeap(func(a, b task) bool { return a.priority < b.priority })
+
+	// lastSeq tracks the sequence of the last popped task for each priority.
+	lastSeq := make(map[int]int)
+	seq := 0
+	for range ops {
+		if h.Len() > 0 && rand.IntN(2) == 0 {
+			v, ok := h.Pop()
+			if !ok {
+				t.Fatal("expected an element")
+			}
+
+			if last, ok := lastSeq[v.priority]; ok && last > v.seq {
+				t.Fatalf("priority %d popped seq %d after %d", v.priority, v.seq, last)
+			}
+			lastSeq[v.priority] = v.seq
+			continue
+		}
+
+		h.Insert(task{priority: rand.IntN(8), seq: seq})
+		seq++
+	}
+
+	prev := -1
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		assert.LessOrEqual(t, prev, v.priority)
+		if last, ok := lastSeq[v.priority]; ok {
+			assert.Less(t, last, v.seq)
+		}
+		lastSeq[v.priority] = v.seq
+		prev = v.priority
+	}
+}
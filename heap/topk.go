@@ -0,0 +1,103 @@
+package heap
+
+import (
+	"iter"
+	"slices"
+)
+
+// TopK keeps the k greatest elements offered to it, as ordered by less.
+// The smallest retained element sits at the root of a fixed-capacity heap,
+// so each Offer costs O(log k).
+type TopK[T any] struct {
+	heap *Heap[T]
+	k    int
+}
+
+// NewTopK creates a collector that retains the k greatest elements according to less.
+func NewTopK[T any](k int, less LessFn[T]) *TopK[T] {
+	h := NewHeap(less)
+	h.buffer = make([]T, 0, max(k, 0))
+
+	return &TopK[T]{heap: h, k: k}
+}
+
+// Offer considers val for inclusion.
+// Returns true if val is retained among the top k elements.
+func (t *TopK[T]) Offer(val T) bool {
+	if t.k <= 0 {
+		return false
+	}
+
+	if t.heap.Len() < t.k {
+		t.heap.Insert(val)
+		return true
+	}
+
+	if !t.heap.lessFn(t.heap.buffer[0], val) {
+		return false
+	}
+
+	t.heap.Replace(val)
+	return true
+}
+
+// Min returns the smallest retained element, which is the threshold a new element must beat once the collector is full.
+func (t *TopK[T]) Min() (T, bool) {
+	return t.heap.Peek()
+}
+
+// Full reports whether k elements have been retained.
+func (t *TopK[T]) Full() bool {
+	return t.heap.Len() >= t.k
+}
+
+// Sorted returns a copy of the retained elements from greatest to smallest.
+func (t *TopK[T]) Sorted() []T {
+	result := slices.Clone(t.heap.buffer)
+	slices.SortFunc(result, func(a, b T) int {
+		switch {
+		case t.heap.lessFn(b, a):
+			return -1
+		case t.heap.lessFn(a, b):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return result
+}
+
+// Merge offers every element retained by other to t.
+// This allows collectors filled by parallel shards to be combined.
+// Merging a collector into itself leaves it unchanged.
+func (t *TopK[T]) Merge(other *TopK[T]) {
+	if other == t {
+		return
+	}
+
+	for _, v := range other.heap.buffer {
+		t.Offer(v)
+	}
+}
+
+// All returns an iterator over the retained elements from smallest to greatest without removing them.
+// The collector must not be modified while iterating.
+func (t *TopK[T]) All() iter.Seq[T] {
+	return t.heap.All()
+}
+
+// Drain returns an iterator that removes the retained elements from smallest to greatest.
+// Stopping the iteration early leaves the remaining elements in the collector.
+func (t *TopK[T]) Drain() iter.Seq[T] {
+	return t.heap.Drain()
+}
+
+// K returns the number of elements the collector retains.
+func (t *TopK[T]) K() int {
+	return t.k
+}
+
+func (t *TopK[T]) Len() int {
+	return t.heap.Len()
+}
@@ -0,0 +1,125 @@
+package heap
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopK(t *testing.T) {
+	tests := map[string]struct {
+		k        int
+		input    []int
+		expected []int
+	}{
+		"fewer than k elements": {
+			k:        5,
+			input:    []int{3, 1, 2},
+			expected: []int{3, 2, 1},
+		},
+		"more than k elements": {
+			k:        3,
+			input:    []int{5, 1, 9, 3, 7, 2, 8},
+			expected: []int{9, 8, 7},
+		},
+		"duplicates": {
+			k:        3,
+			input:    []int{4, 4, 4, 1, 4},
+			expected: []int{4, 4, 4},
+		},
+		"zero k": {
+			k:        0,
+			input:    []int{1, 2, 3},
+			expected: []int{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			topK := NewTopK(tc.k, intLess)
+			for _, v := range tc.input {
+				topK.Offer(v)
+			}
+
+			assert.Equal(t, tc.expected, topK.Sorted())
+			assert.Equal(t, len(tc.expected), topK.Len())
+
+			ascending := slices.Clone(tc.expected)
+			slices.Reverse(ascending)
+			assert.Equal(t, ascending, slices.AppendSeq([]int{}, topK.All()))
+			assert.Equal(t, ascending, slices.AppendSeq([]int{}, topK.Drain()))
+			assert.Equal(t, 0, topK.Len())
+		})
+	}
+}
+
+func TestTopK_Offer(t *testing.T) {
+	topK := NewTopK(2, intLess)
+	_, ok := topK.Min()
+	assert.False(t, ok)
+	assert.False(t, topK.Full())
+
+	assert.True(t, topK.Offer(5))
+	assert.True(t, topK.Offer(3))
+	assert.True(t, topK.Full())
+
+	v, ok := topK.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+
+	assert.False(t, topK.Offer(1))
+	assert.False(t, topK.Offer(3))
+	assert.True(t, topK.Offer(4))
+
+	v, _ = topK.Min()
+	assert.Equal(t, 4, v)
+	assert.Equal(t, 2, topK.K())
+}
+
+func TestTopK_Merge(t *testing.T) {
+	const (
+		k      = 10
+		shards = 4
+	)
+
+	input := make([]int, 10000)
+	for idx := range input {
+		input[idx] = rand.IntN(100000)
+	}
+
+	collectors := make([]*TopK[int], shards)
+	wg := sync.WaitGroup{}
+	wg.Add(shards)
+	for shard := range shards {
+		collectors[shard] = NewTopK(k, intLess)
+		go func() {
+			defer wg.Done()
+			for idx := shard; idx < len(input); idx += shards {
+				collectors[shard].Offer(input[idx])
+			}
+		}()
+	}
+	wg.Wait()
+
+	result := NewTopK(k, intLess)
+	for _, c := range collectors {
+		result.Merge(c)
+	}
+
+	slices.Sort(input)
+	slices.Reverse(input)
+	assert.Equal(t, input[:k], result.Sorted())
+
+	result.Merge(result)
+	assert.Equal(t, input[:k], result.Sorted())
+}
+
+func BenchmarkTopK(b *testing.B) {
+	topK := NewTopK(100, intLess)
+	for idx := range b.N {
+		topK.Offer(idx)
+	}
+}
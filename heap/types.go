@@ -1,9 +1,18 @@
 package heap
 
-import "golang.org/x/exp/constraints"
-
-type PriorityQueue[T constraints.Ordered] interface {
+type PriorityQueue[T any] interface {
 	Insert(v T)
 	Pop() (T, bool)
 	Len() int
 }
+
+// LessFn reports whether a should be popped before b.
+type LessFn[T any] func(a, b T) bool
+
+// ComparatorQueue is a PriorityQueue that exposes the comparator it is ordered by.
+type ComparatorQueue[T any] interface {
+	PriorityQueue[T]
+
+	// Less reports whether a is popped before b.
+	Less(a, b T) bool
+}
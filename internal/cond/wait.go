@@ -0,0 +1,36 @@
+package cond
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when operating on a closed blocking queue.
+var ErrClosed = errors.New("queue is closed")
+
+// Wait blocks on c while blocked reports true.
+// Returns the context error if ctx is done first.
+// The caller must hold c.L, which is also held whenever blocked is called.
+func Wait(ctx context.Context, c *sync.Cond, blocked func() bool) error {
+	if !blocked() {
+		return nil
+	}
+
+	// Wake up the waiters when the context is done so that they can observe ctx.Err().
+	stop := context.AfterFunc(ctx, func() {
+		c.L.Lock()
+		defer c.L.Unlock()
+		c.Broadcast()
+	})
+	defer stop()
+
+	for blocked() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.Wait()
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package queue
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockQueue_Batch(t *testing.T) {
+	tests := map[string]func(t *testing.T, q *LockQueue[int]){
+		"Partial enqueue": func(t *testing.T, q *LockQueue[int]) {
+			assert.Equal(t, 3, q.EnqueueAll([]int{0, 1, 2}))
+			assert.Equal(t, 2, q.EnqueueAll([]int{3, 4, 5, 6}))
+			assert.Equal(t, 0, q.EnqueueAll([]int{7}))
+			assert.Equal(t, 0, q.EnqueueAll(nil))
+			assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(q.All()))
+		},
+		"Partial dequeue": func(t *testing.T, q *LockQueue[int]) {
+			assert.Equal(t, 0, q.DequeueN(make([]int, 3)))
+
+			q.EnqueueAll([]int{0, 1, 2})
+			dst := make([]int, 5)
+			assert.Equal(t, 3, q.DequeueN(dst))
+			assert.Equal(t, []int{0, 1, 2}, dst[:3])
+			assert.Equal(t, 0, q.Len())
+		},
+		"Wraps around the ring buffer": func(t *testing.T, _ *LockQueue[int]) {
+			q := NewLockQueue[int](minDequeSize)
+			assert.Equal(t, 6, q.EnqueueAll([]int{0, 1, 2, 3, 4, 5}))
+			assert.Equal(t, 6, q.DequeueN(make([]int, 6)))
+			assert.Equal(t, 6, q.deque.head)
+
+			// The batch fills the last two slots and continues at the start of the buffer.
+			assert.Equal(t, 5, q.EnqueueAll([]int{6, 7, 8, 9, 10}))
+			assert.Len(t, q.deque.buffer, minDequeSize)
+			assert.Equal(t, []int{6, 7}, q.deque.buffer[6:])
+			assert.Equal(t, []int{6, 7, 8, 9, 10}, slices.Collect(q.All()))
+
+			dst := make([]int, 5)
+			assert.Equal(t, 5, q.DequeueN(dst))
+			assert.Equal(t, []int{6, 7, 8, 9, 10}, dst)
+			assert.Equal(t, 3, q.deque.head)
+			assert.Equal(t, make([]int, minDequeSize), q.deque.buffer)
+		},
+		"Mixed with single operations": func(t *testing.T, q *LockQueue[int]) {
+			assert.True(t, q.Enqueue(0))
+			assert.Equal(t, 2, q.EnqueueAll([]int{1, 2}))
+			assert.True(t, q.Enqueue(3))
+
+			v, ok := q.Dequeue()
+			assert.True(t, ok)
+			assert.Equal(t, 0, v)
+
+			dst := make([]int, 2)
+			assert.Equal(t, 2, q.DequeueN(dst))
+			assert.Equal(t, []int{1, 2}, dst)
+
+			v, _ = q.Dequeue()
+			assert.Equal(t, 3, v)
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc(t, NewLockQueue[int](defaultQSize))
+		})
+	}
+}
+
+func TestLockQueue_BatchUnbounded(t *testing.T) {
+	// A fixed seed keeps failures reproducible.
+	rng := rand.New(rand.NewPCG(1, 2))
+	q := NewLockQueue[int](Unbounded)
+	var expected []int
+	next := 0
+	for range 1000 {
+		if rng.IntN(2) == 0 {
+			batch := make([]int, rng.IntN(50))
+			for idx := range batch {
+				batch[idx] = next
+				next++
+			}
+			assert.Equal(t, len(batch), q.EnqueueAll(batch))
+			expected = append(expected, batch...)
+			continue
+		}
+
+		dst := make([]int, rng.IntN(50))
+		n := q.DequeueN(dst)
+		assert.Equal(t, min(len(dst), len(expected)), n)
+		assert.Equal(t, expected[:n], dst[:n])
+		expected = expected[n:]
+	}
+
+	// slices.Equal treats an empty remainder and a nil iterator result as equal.
+	assert.Equal(t, len(expected), q.Len())
+	assert.True(t, slices.Equal(expected, slices.Collect(q.All())))
+}
+
+func TestLockQueue_BatchUnblocksProducers(t *testing.T) {
+	q := NewLockQueue[int](2).WithOverflowPolicy(Block, nil)
+	q.EnqueueAll([]int{0, 1})
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	for idx := range 2 {
+		go func() {
+			defer wg.Done()
+			assert.True(t, q.Enqueue(2+idx))
+		}()
+	}
+
+	dst := make([]int, 2)
+	assert.Equal(t, 2, q.DequeueN(dst))
+	wg.Wait()
+	assert.Equal(t, 2, q.Len())
+}
+
+func BenchmarkLockQueueBatch(b *testing.B) {
+	const batch = 1000
+	vals := make([]int, batch)
+	dst := make([]int, batch)
+
+	b.Run("Single", func(b *testing.B) {
+		q := NewLockQueue[int](batch)
+		for range b.N {
+			for _, v := range vals {
+				q.Enqueue(v)
+			}
+			for range batch {
+				q.Dequeue()
+			}
+		}
+	})
+
+	b.Run("Batch", func(b *testing.B) {
+		q := NewLockQueue[int](batch)
+		for range b.N {
+			q.EnqueueAll(vals)
+			q.DequeueN(dst)
+		}
+	})
+}
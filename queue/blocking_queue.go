@@ -0,0 +1,161 @@
+package queue
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/Jh123x/go-collections/internal/cond"
+)
+
+var (
+	_ LenQueue[string]  = (*BlockingQueue[string])(nil)
+	_ PeekQueue[string] = (*BlockingQueue[string])(nil)
+)
+
+// ErrClosed is returned when operating on a closed queue.
+var ErrClosed = cond.ErrClosed
+
+// BlockingQueue is a bounded thread-safe queue whose Put and Take block until space or data is available.
+type BlockingQueue[T any] struct {
+	queue    *StdQueue[T]
+	mux      *sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	closed   bool
+}
+
+// NewBlockingQueue creates a queue holding at most len elements, or any number of elements if len is Unbounded.
+func NewBlockingQueue[T any](len int) *BlockingQueue[T] {
+	mux := &sync.Mutex{}
+	return &BlockingQueue[T]{
+		queue:    NewStdQueue[T](len),
+		mux:      mux,
+		notEmpty: sync.NewCond(mux),
+		notFull:  sync.NewCond(mux),
+	}
+}
+
+func (q *BlockingQueue[T]) Len() int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.queue.Len()
+}
+
+// Enqueue adds val to the end of the queue without blocking.
+// Returns false if the queue is full or closed.
+func (q *BlockingQueue[T]) Enqueue(val T) bool {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if q.closed || !q.queue.Enqueue(val) {
+		return false
+	}
+
+	q.notEmpty.Signal()
+	return true
+}
+
+// Dequeue removes the element at the front of the queue without blocking.
+// Returns false if the queue is empty.
+func (q *BlockingQueue[T]) Dequeue() (T, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	v, ok := q.queue.Dequeue()
+	if ok {
+		q.notFull.Signal()
+	}
+
+	return v, ok
+}
+
+func (q *BlockingQueue[T]) Peek() (T, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.queue.Peek()
+}
+
+// Put adds val to the end of the queue, blocking while the queue is full.
+// Returns ErrClosed if the queue is closed or the context error if ctx is done first.
+func (q *BlockingQueue[T]) Put(ctx context.Context, val T) error {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if err := q.wait(ctx, q.notFull, q.isFull); err != nil {
+		return err
+	}
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	q.queue.Enqueue(val)
+	q.notEmpty.Signal()
+	return nil
+}
+
+// Take removes the element at the front of the queue, blocking while the queue is empty.
+// Once the queue is closed, the remaining elements are still returned before ErrClosed.
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	var empty T
+	if err := q.wait(ctx, q.notEmpty, q.isEmpty); err != nil {
+		return empty, err
+	}
+
+	v, ok := q.queue.Dequeue()
+	if !ok {
+		return empty, ErrClosed
+	}
+
+	q.notFull.Signal()
+	return v, nil
+}
+
+// Close stops the queue from accepting new elements and wakes up all blocked callers.
+func (q *BlockingQueue[T]) Close() {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
+
+// All returns an iterator over a snapshot of the elements from front to back.
+// The lock is not held while the consumer runs.
+func (q *BlockingQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		q.mux.Lock()
+		snapshot := q.queue.Snapshot()
+		q.mux.Unlock()
+
+		for _, v := range snapshot {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that dequeues elements without blocking until the queue is empty.
+func (q *BlockingQueue[T]) Drain() iter.Seq[T] {
+	return drain[T](q)
+}
+
+// wait blocks on c while blocked reports true and the queue is open.
+// The caller must hold q.mux.
+func (q *BlockingQueue[T]) wait(ctx context.Context, c *sync.Cond, blocked func() bool) error {
+	return cond.Wait(ctx, c, func() bool { return !q.closed && blocked() })
+}
+
+func (q *BlockingQueue[T]) isEmpty() bool {
+	return q.queue.Len() == 0
+}
+
+func (q *BlockingQueue[T]) isFull() bool {
+	return isFull(q.queue.Len(), q.queue.maxSize)
+}
@@ -0,0 +1,125 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	tests := map[string]func(t *testing.T, q *BlockingQueue[int]){
+		"Take blocks until Put": func(t *testing.T, q *BlockingQueue[int]) {
+			result := make(chan int)
+			go func() {
+				v, err := q.Take(context.Background())
+				assert.NoError(t, err)
+				result <- v
+			}()
+
+			time.Sleep(10 * time.Millisecond)
+			assert.NoError(t, q.Put(context.Background(), 42))
+			assert.Equal(t, 42, <-result)
+		},
+		"Put blocks until Take": func(t *testing.T, q *BlockingQueue[int]) {
+			for idx := range defaultQSize {
+				assert.NoError(t, q.Put(context.Background(), idx))
+			}
+
+			done := make(chan error)
+			go func() { done <- q.Put(context.Background(), defaultQSize) }()
+
+			time.Sleep(10 * time.Millisecond)
+			v, err := q.Take(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 0, v)
+			assert.NoError(t, <-done)
+			assert.Equal(t, defaultQSize, q.Len())
+		},
+		"Take respects context": func(t *testing.T, q *BlockingQueue[int]) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			_, err := q.Take(ctx)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		},
+		"Put respects context": func(t *testing.T, q *BlockingQueue[int]) {
+			for idx := range defaultQSize {
+				assert.True(t, q.Enqueue(idx))
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			assert.ErrorIs(t, q.Put(ctx, defaultQSize), context.DeadlineExceeded)
+			assert.Equal(t, defaultQSize, q.Len())
+		},
+		"Close lets consumers drain": func(t *testing.T, q *BlockingQueue[int]) {
+			assert.NoError(t, q.Put(context.Background(), 1))
+			assert.NoError(t, q.Put(context.Background(), 2))
+			q.Close()
+
+			assert.ErrorIs(t, q.Put(context.Background(), 3), ErrClosed)
+			assert.False(t, q.Enqueue(3))
+
+			for _, expected := range []int{1, 2} {
+				v, err := q.Take(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, expected, v)
+			}
+
+			_, err := q.Take(context.Background())
+			assert.ErrorIs(t, err, ErrClosed)
+		},
+		"Close wakes blocked callers": func(t *testing.T, q *BlockingQueue[int]) {
+			wg := sync.WaitGroup{}
+			wg.Add(defaultQSize)
+			for range defaultQSize {
+				go func() {
+					defer wg.Done()
+					_, err := q.Take(context.Background())
+					assert.ErrorIs(t, err, ErrClosed)
+				}()
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			q.Close()
+			wg.Wait()
+		},
+		"Producers and consumers": func(t *testing.T, q *BlockingQueue[int]) {
+			const count = 1000
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for idx := range count {
+					assert.NoError(t, q.Put(context.Background(), idx))
+				}
+				q.Close()
+			}()
+
+			result := make([]int, 0, count)
+			for {
+				v, err := q.Take(context.Background())
+				if err != nil {
+					assert.ErrorIs(t, err, ErrClosed)
+					break
+				}
+				result = append(result, v)
+			}
+			wg.Wait()
+
+			assert.Len(t, result, count)
+			for idx, v := range result {
+				assert.Equal(t, idx, v)
+			}
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc(t, NewBlockingQueue[int](defaultQSize))
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package queue
+
+import (
+	"container/list"
+	"iter"
+	"sync"
+)
+
+var (
+	_ LenQueue[string]  = (*DedupQueue[string, string])(nil)
+	_ PeekQueue[string] = (*DedupQueue[string, string])(nil)
+)
+
+// MergeFn combines an element already pending in a DedupQueue with a new element for the same key.
+type MergeFn[T any] func(pending, incoming T) T
+
+type dedupEntry[K comparable, T any] struct {
+	key K
+	val T
+}
+
+// DedupQueue is a thread-safe queue that holds at most one pending element per key.
+// Enqueueing an element whose key is already pending keeps the original position in the queue
+// and either ignores the new element or merges it into the pending one.
+type DedupQueue[K comparable, T any] struct {
+	mux     *sync.Mutex
+	list    *list.List
+	pending map[K]*list.Element
+	keyFn   KeyFn[K, T]
+	mergeFn MergeFn[T]
+	maxSize int
+}
+
+// NewDedupQueue creates a queue deduplicating elements by keyFn.
+// It holds at most maxSize keys, or any number of keys if maxSize is Unbounded.
+func NewDedupQueue[K comparable, T any](keyFn KeyFn[K, T], maxSize int) *DedupQueue[K, T] {
+	return &DedupQueue[K, T]{
+		mux:     &sync.Mutex{},
+		list:    list.New(),
+		pending: make(map[K]*list.Element),
+		keyFn:   keyFn,
+		maxSize: maxSize,
+	}
+}
+
+// WithMerge sets the function used to combine an incoming element with the pending element of the same key.
+// Without it, incoming elements for pending keys are ignored.
+func (q *DedupQueue[K, T]) WithMerge(merge MergeFn[T]) *DedupQueue[K, T] {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	q.mergeFn = merge
+	return q
+}
+
+func (q *DedupQueue[K, T]) Len() int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.list.Len()
+}
+
+// Contains reports whether an element with key is pending.
+func (q *DedupQueue[K, T]) Contains(key K) bool {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	_, ok := q.pending[key]
+	return ok
+}
+
+// Enqueue adds val to the end of the queue unless an element with the same key is pending.
+// Returns false only if the key is not pending and the queue is full.
+func (q *DedupQueue[K, T]) Enqueue(val T) bool {
+	key := q.keyFn(val)
+
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if e, ok := q.pending[key]; ok {
+		if q.mergeFn != nil {
+			entry := e.Value.(dedupEntry[K, T])
+			entry.val = q.mergeFn(entry.val, val)
+			e.Value = entry
+		}
+		return true
+	}
+
+	if isFull(q.list.Len(), q.maxSize) {
+		return false
+	}
+
+	q.pending[key] = q.list.PushBack(dedupEntry[K, T]{key: key, val: val})
+	return true
+}
+
+func (q *DedupQueue[K, T]) Dequeue() (T, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	e := q.list.Front()
+	if e == nil {
+		var empty T
+		return empty, false
+	}
+
+	entry := q.list.Remove(e).(dedupEntry[K, T])
+	delete(q.pending, entry.key)
+	return entry.val, true
+}
+
+func (q *DedupQueue[K, T]) Peek() (T, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	e := q.list.Front()
+	if e == nil {
+		var empty T
+		return empty, false
+	}
+
+	return e.Value.(dedupEntry[K, T]).val, true
+}
+
+// All returns an iterator over a snapshot of the pending elements from front to back.
+// The lock is not held while the consumer runs.
+func (q *DedupQueue[K, T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		q.mux.Lock()
+		snapshot := make([]T, 0, q.list.Len())
+		for e := q.list.Front(); e != nil; e = e.Next() {
+			snapshot = append(snapshot, e.Value.(dedupEntry[K, T]).val)
+		}
+		q.mux.Unlock()
+
+		for _, v := range snapshot {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that dequeues elements until the queue is empty.
+func (q *DedupQueue[K, T]) Drain() iter.Seq[T] {
+	return drain[T](q)
+}
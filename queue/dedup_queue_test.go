@@ -0,0 +1,130 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type invalidation struct {
+	key   string
+	count int
+}
+
+func invalidationKey(i invalidation) string {
+	return i.key
+}
+
+func TestDedupQueue(t *testing.T) {
+	tests := map[string]struct {
+		merge    MergeFn[invalidation]
+		enqueue  []invalidation
+		expected []invalidation
+	}{
+		"ignores pending keys": {
+			enqueue: []invalidation{
+				{"a", 1}, {"b", 1}, {"a", 2}, {"c", 1}, {"b", 2},
+			},
+			expected: []invalidation{{"a", 1}, {"b", 1}, {"c", 1}},
+		},
+		"merges pending keys": {
+			merge: func(pending, incoming invalidation) invalidation {
+				pending.count += incoming.count
+				return pending
+			},
+			enqueue: []invalidation{
+				{"a", 1}, {"b", 1}, {"a", 2}, {"c", 1}, {"a", 3},
+			},
+			expected: []invalidation{{"a", 6}, {"b", 1}, {"c", 1}},
+		},
+		"replaces pending keys": {
+			merge: func(_, incoming invalidation) invalidation {
+				return incoming
+			},
+			enqueue: []invalidation{
+				{"a", 1}, {"b", 1}, {"a", 2},
+			},
+			expected: []invalidation{{"a", 2}, {"b", 1}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			q := NewDedupQueue(invalidationKey, Unbounded)
+			if tc.merge != nil {
+				q.WithMerge(tc.merge)
+			}
+
+			for _, v := range tc.enqueue {
+				assert.True(t, q.Enqueue(v))
+			}
+			assert.Equal(t, len(tc.expected), q.Len())
+
+			for _, expected := range tc.expected {
+				v, ok := q.Dequeue()
+				assert.True(t, ok)
+				assert.Equal(t, expected, v)
+			}
+
+			_, ok := q.Dequeue()
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestDedupQueue_RequeueAfterDequeue(t *testing.T) {
+	q := NewDedupQueue(invalidationKey, Unbounded)
+	assert.True(t, q.Enqueue(invalidation{"a", 1}))
+	assert.True(t, q.Contains("a"))
+
+	v, ok := q.Dequeue()
+	assert.True(t, ok)
+	assert.Equal(t, invalidation{"a", 1}, v)
+	assert.False(t, q.Contains("a"))
+
+	assert.True(t, q.Enqueue(invalidation{"a", 2}))
+	assert.Equal(t, 1, q.Len())
+}
+
+func TestDedupQueue_Capacity(t *testing.T) {
+	q := NewDedupQueue(invalidationKey, 2)
+	assert.True(t, q.Enqueue(invalidation{"a", 1}))
+	assert.True(t, q.Enqueue(invalidation{"b", 1}))
+	assert.False(t, q.Enqueue(invalidation{"c", 1}))
+
+	// Pending keys are still accepted when the queue is full.
+	assert.True(t, q.Enqueue(invalidation{"a", 2}))
+	assert.Equal(t, 2, q.Len())
+}
+
+func TestDedupQueue_Concurrent(t *testing.T) {
+	const (
+		producers = 8
+		keys      = 100
+	)
+
+	q := NewDedupQueue(invalidationKey, Unbounded).WithMerge(func(pending, incoming invalidation) invalidation {
+		pending.count += incoming.count
+		return pending
+	})
+
+	wg := sync.WaitGroup{}
+	wg.Add(producers)
+	for range producers {
+		go func() {
+			defer wg.Done()
+			for idx := range keys {
+				assert.True(t, q.Enqueue(invalidation{key: string(rune(idx)), count: 1}))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, keys, q.Len())
+	for range keys {
+		v, ok := q.Dequeue()
+		assert.True(t, ok)
+		assert.Equal(t, producers, v.count)
+	}
+}
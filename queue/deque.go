@@ -0,0 +1,229 @@
+package queue
+
+import (
+	"iter"
+
+	"github.com/Jh123x/go-collections/stack"
+)
+
+var (
+	_ LenQueue[string]       = (*Deque[string])(nil)
+	_ PeekQueue[string]      = (*Deque[string])(nil)
+	_ stack.LenStack[string] = (*Deque[string])(nil)
+)
+
+const minDequeSize = 8
+
+// Deque is a double ended queue backed by a ring buffer that grows and shrinks as needed.
+// All operations at either end are amortized O(1).
+//
+// As a Queue, elements are enqueued at the back and dequeued from the front.
+// As a Stack, the front of the deque is the top of the stack.
+type Deque[T any] struct {
+	buffer  []T
+	head    int
+	size    int
+	minSize int
+}
+
+// NewDeque creates an empty deque with room for capacity elements before it has to grow.
+// The buffer never shrinks below this capacity.
+func NewDeque[T any](capacity int) *Deque[T] {
+	size := max(capacity, minDequeSize)
+	return &Deque[T]{buffer: make([]T, size), minSize: size}
+}
+
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// PushFront adds val to the front of the deque.
+func (d *Deque[T]) PushFront(val T) {
+	d.grow()
+	d.head = d.index(-1)
+	d.buffer[d.head] = val
+	d.size++
+}
+
+// PushBack adds val to the back of the deque.
+func (d *Deque[T]) PushBack(val T) {
+	d.grow()
+	d.buffer[d.index(d.size)] = val
+	d.size++
+}
+
+// PopFront removes and returns the element at the front of the deque.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var empty T
+	if d.size == 0 {
+		return empty, false
+	}
+
+	val := d.buffer[d.head]
+	d.buffer[d.head] = empty
+	d.head = d.index(1)
+	d.size--
+	d.shrink()
+
+	return val, true
+}
+
+// PopBack removes and returns the element at the back of the deque.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var empty T
+	if d.size == 0 {
+		return empty, false
+	}
+
+	idx := d.index(d.size - 1)
+	val := d.buffer[idx]
+	d.buffer[idx] = empty
+	d.size--
+	d.shrink()
+
+	return val, true
+}
+
+// PeekFront returns the element at the front of the deque without removing it.
+func (d *Deque[T]) PeekFront() (T, bool) {
+	return d.At(0)
+}
+
+// PeekBack returns the element at the back of the deque without removing it.
+func (d *Deque[T]) PeekBack() (T, bool) {
+	return d.At(d.size - 1)
+}
+
+// At returns the element at position i, counting from the front.
+// Returns false if i is out of range.
+func (d *Deque[T]) At(i int) (T, bool) {
+	if i < 0 || i >= d.size {
+		var empty T
+		return empty, false
+	}
+
+	return d.buffer[d.index(i)], true
+}
+
+// Enqueue adds val to the back of the deque. It always succeeds.
+func (d *Deque[T]) Enqueue(val T) bool {
+	d.PushBack(val)
+	return true
+}
+
+// Dequeue removes and returns the element at the front of the deque.
+func (d *Deque[T]) Dequeue() (T, bool) {
+	return d.PopFront()
+}
+
+// Push adds val to the front of the deque. It always succeeds.
+func (d *Deque[T]) Push(val T) bool {
+	d.PushFront(val)
+	return true
+}
+
+// Pop removes and returns the element at the front of the deque.
+func (d *Deque[T]) Pop() (T, bool) {
+	return d.PopFront()
+}
+
+// Peek returns the element at the front of the deque, which is both the front of the queue and the top of the stack.
+func (d *Deque[T]) Peek() (T, bool) {
+	return d.PeekFront()
+}
+
+// All returns an iterator over the elements from front to back without removing them.
+// The deque must not be modified while iterating.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.size {
+			if !yield(d.buffer[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes elements from the front until the deque is empty.
+func (d *Deque[T]) Drain() iter.Seq[T] {
+	return drain[T](d)
+}
+
+// index returns the buffer index of the element at position i from the front.
+func (d *Deque[T]) index(i int) int {
+	idx := (d.head + i) % len(d.buffer)
+	if idx < 0 {
+		idx += len(d.buffer)
+	}
+
+	return idx
+}
+
+// pushBackAll copies vals to the back of the deque.
+func (d *Deque[T]) pushBackAll(vals []T) {
+	d.reserve(len(vals))
+
+	// The free space starts at the tail and may wrap around to the start of the buffer.
+	n := copy(d.buffer[d.index(d.size):], vals)
+	copy(d.buffer, vals[n:])
+	d.size += len(vals)
+}
+
+// popFrontInto moves up to len(dst) elements from the front of the deque into dst.
+// Returns the number of elements moved.
+func (d *Deque[T]) popFrontInto(dst []T) int {
+	count := min(len(dst), d.size)
+	end := min(d.head+count, len(d.buffer))
+
+	n := copy(dst, d.buffer[d.head:end])
+	clear(d.buffer[d.head:end])
+	copy(dst[n:count], d.buffer[:count-n])
+	clear(d.buffer[:count-n])
+
+	d.head = d.index(count)
+	d.size -= count
+	d.shrink()
+
+	return count
+}
+
+func (d *Deque[T]) grow() {
+	d.reserve(1)
+}
+
+// reserve grows the buffer until there is room for n more elements.
+func (d *Deque[T]) reserve(n int) {
+	size := len(d.buffer)
+	for d.size+n > size {
+		size *= 2
+	}
+
+	if size != len(d.buffer) {
+		d.resize(size)
+	}
+}
+
+func (d *Deque[T]) shrink() {
+	size := len(d.buffer)
+	for size/2 >= d.minSize && d.size <= size/4 {
+		size /= 2
+	}
+
+	if size != len(d.buffer) {
+		d.resize(size)
+	}
+}
+
+// resize copies the elements into a new buffer of the given size, starting at index 0.
+func (d *Deque[T]) resize(size int) {
+	buffer := make([]T, size)
+	if d.head+d.size <= len(d.buffer) {
+		copy(buffer, d.buffer[d.head:d.head+d.size])
+	} else {
+		n := copy(buffer, d.buffer[d.head:])
+		copy(buffer[n:], d.buffer[:d.size-n])
+	}
+
+	d.buffer = buffer
+	d.head = 0
+}
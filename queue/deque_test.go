@@ -0,0 +1,151 @@
+package queue
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/Jh123x/go-collections/stack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeque(t *testing.T) {
+	tests := map[string]func(t *testing.T, d *Deque[int]){
+		"Empty": func(t *testing.T, d *Deque[int]) {
+			_, ok := d.PopFront()
+			assert.False(t, ok)
+			_, ok = d.PopBack()
+			assert.False(t, ok)
+			_, ok = d.PeekFront()
+			assert.False(t, ok)
+			_, ok = d.PeekBack()
+			assert.False(t, ok)
+			_, ok = d.At(0)
+			assert.False(t, ok)
+			assert.Equal(t, 0, d.Len())
+		},
+		"Both ends": func(t *testing.T, d *Deque[int]) {
+			d.PushBack(2)
+			d.PushFront(1)
+			d.PushBack(3)
+			d.PushFront(0)
+
+			assert.Equal(t, []int{0, 1, 2, 3}, slices.Collect(d.All()))
+
+			v, ok := d.PeekFront()
+			assert.True(t, ok)
+			assert.Equal(t, 0, v)
+
+			v, ok = d.PeekBack()
+			assert.True(t, ok)
+			assert.Equal(t, 3, v)
+
+			v, ok = d.At(2)
+			assert.True(t, ok)
+			assert.Equal(t, 2, v)
+			_, ok = d.At(-1)
+			assert.False(t, ok)
+			_, ok = d.At(4)
+			assert.False(t, ok)
+
+			v, _ = d.PopBack()
+			assert.Equal(t, 3, v)
+			v, _ = d.PopFront()
+			assert.Equal(t, 0, v)
+			assert.Equal(t, []int{1, 2}, slices.Collect(d.Drain()))
+		},
+		"Queue semantics": func(t *testing.T, d *Deque[int]) {
+			var q LenQueue[int] = d
+			for idx := range 100 {
+				assert.True(t, q.Enqueue(idx))
+			}
+
+			for idx := range 100 {
+				v, ok := q.Dequeue()
+				assert.True(t, ok)
+				assert.Equal(t, idx, v)
+			}
+			assert.Equal(t, 0, q.Len())
+		},
+		"Stack semantics": func(t *testing.T, d *Deque[int]) {
+			var s stack.LenStack[int] = d
+			for idx := range 100 {
+				assert.True(t, s.Push(idx))
+			}
+
+			for idx := range 100 {
+				v, ok := s.Pop()
+				assert.True(t, ok)
+				assert.Equal(t, 99-idx, v)
+			}
+			assert.Equal(t, 0, s.Len())
+		},
+		"Grows and shrinks": func(t *testing.T, d *Deque[int]) {
+			for idx := range 1000 {
+				d.PushBack(idx)
+			}
+			assert.GreaterOrEqual(t, len(d.buffer), 1000)
+
+			for range 990 {
+				d.PopFront()
+			}
+			assert.Less(t, len(d.buffer), 100)
+			assert.GreaterOrEqual(t, len(d.buffer), minDequeSize)
+			assert.Equal(t, []int{990, 991, 992, 993, 994, 995, 996, 997, 998, 999}, slices.Collect(d.All()))
+		},
+		"Random against slice": func(t *testing.T, d *Deque[int]) {
+			expected := make([]int, 0)
+			for range 10000 {
+				switch rand.IntN(4) {
+				case 0:
+					v := rand.Int()
+					d.PushFront(v)
+					expected = slices.Insert(expected, 0, v)
+				case 1:
+					v := rand.Int()
+					d.PushBack(v)
+					expected = append(expected, v)
+				case 2:
+					v, ok := d.PopFront()
+					assert.Equal(t, len(expected) > 0, ok)
+					if ok {
+						assert.Equal(t, expected[0], v)
+						expected = expected[1:]
+					}
+				default:
+					v, ok := d.PopBack()
+					assert.Equal(t, len(expected) > 0, ok)
+					if ok {
+						assert.Equal(t, expected[len(expected)-1], v)
+						expected = expected[:len(expected)-1]
+					}
+				}
+				assert.Equal(t, len(expected), d.Len())
+			}
+
+			for idx, e := range expected {
+				v, ok := d.At(idx)
+				assert.True(t, ok)
+				assert.Equal(t, e, v)
+			}
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc(t, NewDeque[int](0))
+		})
+	}
+}
+
+func TestDeque_MinimumCapacity(t *testing.T) {
+	d := NewDeque[int](100)
+	for idx := range 1000 {
+		d.PushBack(idx)
+	}
+	for range 1000 {
+		d.PopBack()
+	}
+
+	assert.Equal(t, 100, len(d.buffer))
+}
@@ -0,0 +1,540 @@
+package queue
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	_ LenQueue[string]  = (*DiskQueue[string])(nil)
+	_ PeekQueue[string] = (*DiskQueue[string])(nil)
+)
+
+var (
+	// ErrEmpty is returned when taking from an empty queue.
+	ErrEmpty = errors.New("queue: queue is empty")
+
+	// ErrCorrupted is returned when a segment that is not being written to contains an invalid record.
+	ErrCorrupted = errors.New("queue: corrupted segment")
+)
+
+const (
+	segmentPrefix    = "segment-"
+	segmentSuffix    = ".log"
+	offsetFile       = "consumer.offset"
+	recordHeaderSize = 8
+	offsetRecordSize = 20
+
+	// DefaultSegmentSize is the segment size used when DiskQueueOptions.SegmentSize is not set.
+	DefaultSegmentSize = 64 << 20
+)
+
+// Encoder converts the elements of a DiskQueue to and from bytes.
+type Encoder[T any] interface {
+	Encode(T) ([]byte, error)
+	Decode([]byte) (T, error)
+}
+
+// JSONEncoder encodes elements using encoding/json.
+type JSONEncoder[T any] struct{}
+
+func (JSONEncoder[T]) Encode(val T) ([]byte, error) {
+	return json.Marshal(val)
+}
+
+func (JSONEncoder[T]) Decode(data []byte) (T, error) {
+	var val T
+	err := json.Unmarshal(data, &val)
+	return val, err
+}
+
+// DiskQueueOptions configures a DiskQueue.
+type DiskQueueOptions struct {
+	// SegmentSize is the size in bytes after which a new segment file is started.
+	SegmentSize int64
+
+	// Sync flushes every write and consumer offset to stable storage before returning.
+	// Without it, a crash may lose recent writes and redeliver elements that were already taken.
+	Sync bool
+}
+
+// DiskQueue is a thread-safe unbounded queue persisted to a directory.
+//
+// Elements are appended to segment files as length prefixed, checksummed records.
+// The position of the consumer is stored separately so that the queue resumes where it left off after a restart.
+// A torn record at the end of the last segment, left by a crash during a write, is truncated when the queue is opened.
+// Segments are deleted once every record in them has been consumed.
+type DiskQueue[T any] struct {
+	dir     string
+	encoder Encoder[T]
+	opts    DiskQueueOptions
+	mux     *sync.Mutex
+
+	// segments holds the ids of the segment files from oldest to newest.
+	segments []uint64
+
+	writer      *os.File
+	writeOffset int64
+
+	reader     *os.File
+	readID     uint64
+	readOffset int64
+
+	// offset holds the consumer position, overwritten in place after every Take.
+	offset *os.File
+
+	len    int
+	closed bool
+}
+
+// OpenDiskQueue opens the queue stored in dir, creating it if it does not exist.
+func OpenDiskQueue[T any](dir string, encoder Encoder[T], opts DiskQueueOptions) (*DiskQueue[T], error) {
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = DefaultSegmentSize
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	q := &DiskQueue[T]{dir: dir, encoder: encoder, opts: opts, mux: &sync.Mutex{}}
+	if err := q.recover(); err != nil {
+		q.closeFiles()
+		return nil, err
+	}
+
+	return q, nil
+}
+
+func (q *DiskQueue[T]) Len() int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.len
+}
+
+// Enqueue appends val to the queue.
+// Returns false if val could not be encoded or written, use Put to get the error.
+func (q *DiskQueue[T]) Enqueue(val T) bool {
+	return q.Put(val) == nil
+}
+
+// Dequeue removes the element at the front of the queue.
+// Returns false if the queue is empty or the element could not be read, use Take to get the error.
+func (q *DiskQueue[T]) Dequeue() (T, bool) {
+	val, err := q.Take()
+	return val, err == nil
+}
+
+// Put appends val to the queue.
+func (q *DiskQueue[T]) Put(val T) error {
+	data, err := q.encoder.Encode(val)
+	if err != nil {
+		return err
+	}
+
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	record := make([]byte, recordHeaderSize+len(data))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(data)))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(data))
+	copy(record[recordHeaderSize:], data)
+
+	if q.writeOffset > 0 && q.writeOffset+int64(len(record)) > q.opts.SegmentSize {
+		if err := q.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := q.writer.Write(record)
+	if err != nil {
+		// Drop the partial record so that the segment stays readable.
+		if truncErr := q.writer.Truncate(q.writeOffset); truncErr == nil {
+			_, _ = q.writer.Seek(q.writeOffset, io.SeekStart)
+		} else {
+			q.writeOffset += int64(n)
+		}
+		return err
+	}
+	q.writeOffset += int64(n)
+
+	if q.opts.Sync {
+		if err := q.writer.Sync(); err != nil {
+			return err
+		}
+	}
+
+	q.len++
+	return nil
+}
+
+// Take removes the element at the front of the queue.
+// Returns ErrEmpty if there are no elements.
+// A record that cannot be decoded is still consumed so that it does not block the queue.
+func (q *DiskQueue[T]) Take() (T, error) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	var empty T
+	data, next, err := q.readFront()
+	if err != nil {
+		return empty, err
+	}
+
+	// Persist the new position first so that a failure leaves the element at the front of the queue.
+	if err := q.saveOffset(q.readID, next); err != nil {
+		return empty, err
+	}
+
+	q.readOffset = next
+	q.len--
+	return q.encoder.Decode(data)
+}
+
+// Peek returns the element at the front of the queue without removing it.
+// Returns false if the queue is empty or the element could not be read.
+func (q *DiskQueue[T]) Peek() (T, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	var empty T
+	data, _, err := q.readFront()
+	if err != nil {
+		return empty, false
+	}
+
+	val, err := q.encoder.Decode(data)
+	return val, err == nil
+}
+
+// readFront reads the record at the front of the queue and returns its payload and the offset of the next record.
+// The caller must hold q.mux.
+func (q *DiskQueue[T]) readFront() ([]byte, int64, error) {
+	if q.closed {
+		return nil, 0, ErrClosed
+	}
+
+	if q.len == 0 {
+		return nil, 0, ErrEmpty
+	}
+
+	if err := q.advanceSegment(); err != nil {
+		return nil, 0, err
+	}
+
+	return readRecord(q.reader, q.readOffset)
+}
+
+// Close releases the files held by the queue.
+func (q *DiskQueue[T]) Close() error {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if q.closed {
+		return nil
+	}
+
+	q.closed = true
+	return q.closeFiles()
+}
+
+// recover rebuilds the state of the queue from the files in its directory.
+func (q *DiskQueue[T]) recover() error {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, segmentPrefix) || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+
+		id, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, segmentPrefix), segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		q.segments = append(q.segments, id)
+	}
+	slices.Sort(q.segments)
+
+	if len(q.segments) == 0 {
+		q.segments = append(q.segments, 0)
+	}
+
+	q.offset, err = os.OpenFile(filepath.Join(q.dir, offsetFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	q.readID, q.readOffset, err = q.loadOffset()
+	if err != nil {
+		return err
+	}
+
+	// Segments before the consumer have been fully consumed but may not have been deleted yet.
+	for len(q.segments) > 1 && q.segments[0] < q.readID {
+		if err := os.Remove(q.segmentPath(q.segments[0])); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		q.segments = q.segments[1:]
+	}
+
+	if q.segments[0] != q.readID {
+		q.readID, q.readOffset = q.segments[0], 0
+	}
+
+	for idx, id := range q.segments {
+		last := idx == len(q.segments)-1
+		start := int64(0)
+		if id == q.readID {
+			start = q.readOffset
+		}
+
+		if err := q.scanSegment(id, start, last); err != nil {
+			return err
+		}
+	}
+
+	reader, err := os.Open(q.segmentPath(q.readID))
+	if err != nil {
+		return err
+	}
+	q.reader = reader
+
+	return nil
+}
+
+// scanSegment counts the records in a segment starting from offset.
+// The last segment is opened for writing and truncated after its last valid record.
+func (q *DiskQueue[T]) scanSegment(id uint64, offset int64, last bool) error {
+	flags := os.O_RDONLY
+	if last {
+		flags = os.O_RDWR | os.O_CREATE
+	}
+
+	f, err := os.OpenFile(q.segmentPath(id), flags, 0o644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	for {
+		_, next, err := readRecord(f, offset)
+		if err == nil {
+			q.len++
+			offset = next
+			continue
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		// Only a record cut short by the end of the last segment, or whose payload was not fully
+		// written before the end of it, can be the result of a crash during a write.
+		// Anything else means records that were acknowledged have been damaged.
+		torn := errors.Is(err, io.ErrUnexpectedEOF) || (errors.Is(err, ErrCorrupted) && next == info.Size())
+		if !last || !torn {
+			f.Close()
+			if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, ErrCorrupted) {
+				return fmt.Errorf("%w: %s at offset %d", ErrCorrupted, q.segmentPath(id), offset)
+			}
+			return err
+		}
+
+		if err := f.Truncate(offset); err != nil {
+			f.Close()
+			return err
+		}
+		break
+	}
+
+	if !last {
+		return f.Close()
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return err
+	}
+
+	q.writer = f
+	q.writeOffset = offset
+	return nil
+}
+
+// rotate starts a new segment for writing.
+func (q *DiskQueue[T]) rotate() error {
+	// Only the last segment may end in a torn record, so the current one has to be
+	// on stable storage before a newer segment exists.
+	if err := q.writer.Sync(); err != nil {
+		return err
+	}
+
+	id := q.segments[len(q.segments)-1] + 1
+	f, err := os.OpenFile(q.segmentPath(id), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	q.writer.Close()
+	q.writer = f
+	q.writeOffset = 0
+	q.segments = append(q.segments, id)
+	return nil
+}
+
+// advanceSegment moves the reader to the next segment once the current one has been consumed,
+// deleting the consumed segment.
+func (q *DiskQueue[T]) advanceSegment() error {
+	for len(q.segments) > 1 && q.segments[0] == q.readID {
+		info, err := q.reader.Stat()
+		if err != nil {
+			return err
+		}
+
+		if q.readOffset < info.Size() {
+			return nil
+		}
+
+		next := q.segments[1]
+		reader, err := os.Open(q.segmentPath(next))
+		if err != nil {
+			return err
+		}
+
+		if err := q.saveOffset(next, 0); err != nil {
+			reader.Close()
+			return err
+		}
+
+		q.reader.Close()
+		q.reader = reader
+		q.readID, q.readOffset = next, 0
+
+		if err := os.Remove(q.segmentPath(q.segments[0])); err != nil {
+			return err
+		}
+		q.segments = q.segments[1:]
+	}
+
+	return nil
+}
+
+// loadOffset reads the consumer position, defaulting to the start of the oldest segment
+// if there is none or it is invalid.
+func (q *DiskQueue[T]) loadOffset() (uint64, int64, error) {
+	data := make([]byte, offsetRecordSize)
+	n, err := q.offset.ReadAt(data, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, 0, err
+	}
+
+	// A torn offset record, left by a crash while it was being overwritten,
+	// costs redelivering the oldest segment rather than the whole queue.
+	if n != offsetRecordSize || crc32.ChecksumIEEE(data[:16]) != binary.LittleEndian.Uint32(data[16:]) {
+		return q.segments[0], 0, nil
+	}
+
+	return binary.LittleEndian.Uint64(data[0:8]), int64(binary.LittleEndian.Uint64(data[8:16])), nil
+}
+
+// saveOffset overwrites the stored consumer position with the given segment and offset.
+func (q *DiskQueue[T]) saveOffset(id uint64, offset int64) error {
+	data := make([]byte, offsetRecordSize)
+	binary.LittleEndian.PutUint64(data[0:8], id)
+	binary.LittleEndian.PutUint64(data[8:16], uint64(offset))
+	binary.LittleEndian.PutUint32(data[16:], crc32.ChecksumIEEE(data[:16]))
+
+	if _, err := q.offset.WriteAt(data, 0); err != nil {
+		return err
+	}
+
+	if q.opts.Sync {
+		return q.offset.Sync()
+	}
+
+	return nil
+}
+
+func (q *DiskQueue[T]) segmentPath(id uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%s%020d%s", segmentPrefix, id, segmentSuffix))
+}
+
+func (q *DiskQueue[T]) closeFiles() error {
+	var errs []error
+	if q.reader != nil {
+		errs = append(errs, q.reader.Close())
+	}
+	if q.writer != nil {
+		errs = append(errs, q.writer.Close())
+	}
+	if q.offset != nil {
+		errs = append(errs, q.offset.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// readRecord reads the record at offset and returns its payload and the offset of the next record.
+// Returns io.EOF if there is no record at offset and io.ErrUnexpectedEOF if the record runs past the end of the file.
+// Returns ErrCorrupted if the checksum does not match, along with the offset the record ends at.
+func readRecord(f *os.File, offset int64) ([]byte, int64, error) {
+	header := make([]byte, recordHeaderSize)
+	n, err := f.ReadAt(header, offset)
+	if n == 0 && errors.Is(err, io.EOF) {
+		return nil, offset, io.EOF
+	}
+	if n < recordHeaderSize {
+		if err == nil || errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, offset, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, offset, err
+	}
+
+	size := int64(binary.LittleEndian.Uint32(header[0:4]))
+	if offset+recordHeaderSize+size > info.Size() {
+		return nil, offset, io.ErrUnexpectedEOF
+	}
+
+	data := make([]byte, size)
+	if _, err := f.ReadAt(data, offset+recordHeaderSize); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, offset, err
+	}
+
+	next := offset + recordHeaderSize + size
+	if crc32.ChecksumIEEE(data) != binary.LittleEndian.Uint32(header[4:8]) {
+		return nil, next, ErrCorrupted
+	}
+
+	return data, next, nil
+}
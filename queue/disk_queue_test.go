@@ -0,0 +1,354 @@
+package queue
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type event struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func openEventQueue(t *testing.T, dir string, segmentSize int64) *DiskQueue[event] {
+	q, err := OpenDiskQueue[event](dir, JSONEncoder[event]{}, DiskQueueOptions{SegmentSize: segmentSize, Sync: true})
+	require.NoError(t, err)
+	return q
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, segmentPrefix+"*"+segmentSuffix))
+	require.NoError(t, err)
+	return files
+}
+
+func TestDiskQueue(t *testing.T) {
+	tests := map[string]func(t *testing.T, dir string){
+		"FIFO": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 0)
+			defer q.Close()
+
+			_, err := q.Take()
+			assert.ErrorIs(t, err, ErrEmpty)
+
+			for idx := range 100 {
+				assert.True(t, q.Enqueue(event{ID: idx, Name: "e" + strconv.Itoa(idx)}))
+			}
+			assert.Equal(t, 100, q.Len())
+
+			for idx := range 100 {
+				v, ok := q.Dequeue()
+				assert.True(t, ok)
+				assert.Equal(t, event{ID: idx, Name: "e" + strconv.Itoa(idx)}, v)
+			}
+
+			_, ok := q.Dequeue()
+			assert.False(t, ok)
+			assert.Equal(t, 0, q.Len())
+		},
+		"Survives restart": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 0)
+			for idx := range 10 {
+				require.NoError(t, q.Put(event{ID: idx}))
+			}
+			for idx := range 4 {
+				v, err := q.Take()
+				require.NoError(t, err)
+				assert.Equal(t, idx, v.ID)
+			}
+			require.NoError(t, q.Close())
+
+			q = openEventQueue(t, dir, 0)
+			defer q.Close()
+
+			assert.Equal(t, 6, q.Len())
+			require.NoError(t, q.Put(event{ID: 10}))
+			for idx := 4; idx <= 10; idx++ {
+				v, err := q.Take()
+				require.NoError(t, err)
+				assert.Equal(t, idx, v.ID)
+			}
+		},
+		"Rotates and compacts segments": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 64)
+			defer q.Close()
+
+			for idx := range 20 {
+				require.NoError(t, q.Put(event{ID: idx}))
+			}
+			assert.Greater(t, len(segmentFiles(t, dir)), 5)
+
+			for idx := range 20 {
+				v, err := q.Take()
+				require.NoError(t, err)
+				assert.Equal(t, idx, v.ID)
+			}
+			assert.Len(t, segmentFiles(t, dir), 1)
+
+			// The queue keeps working after the segments have been compacted.
+			require.NoError(t, q.Put(event{ID: 20}))
+			v, err := q.Take()
+			require.NoError(t, err)
+			assert.Equal(t, 20, v.ID)
+		},
+		"Restart across segments": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 64)
+			for idx := range 20 {
+				require.NoError(t, q.Put(event{ID: idx}))
+			}
+			for range 7 {
+				_, err := q.Take()
+				require.NoError(t, err)
+			}
+			require.NoError(t, q.Close())
+
+			q = openEventQueue(t, dir, 64)
+			defer q.Close()
+
+			assert.Equal(t, 13, q.Len())
+			for idx := 7; idx < 20; idx++ {
+				v, err := q.Take()
+				require.NoError(t, err)
+				assert.Equal(t, idx, v.ID)
+			}
+		},
+		"Truncates torn write": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 0)
+			for idx := range 3 {
+				require.NoError(t, q.Put(event{ID: idx}))
+			}
+			require.NoError(t, q.Close())
+
+			// Simulate a crash in the middle of writing a record.
+			files := segmentFiles(t, dir)
+			f, err := os.OpenFile(files[len(files)-1], os.O_WRONLY|os.O_APPEND, 0o644)
+			require.NoError(t, err)
+			_, err = f.Write([]byte{42, 0, 0, 0, 1, 2})
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			q = openEventQueue(t, dir, 0)
+			defer q.Close()
+
+			assert.Equal(t, 3, q.Len())
+			require.NoError(t, q.Put(event{ID: 3}))
+			for idx := range 4 {
+				v, err := q.Take()
+				require.NoError(t, err)
+				assert.Equal(t, idx, v.ID)
+			}
+		},
+		"Truncates record with bad checksum": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 0)
+			for idx := range 3 {
+				require.NoError(t, q.Put(event{ID: idx}))
+			}
+			require.NoError(t, q.Close())
+
+			// Flip the last byte of the last record.
+			files := segmentFiles(t, dir)
+			data, err := os.ReadFile(files[len(files)-1])
+			require.NoError(t, err)
+			data[len(data)-1] ^= 0xff
+			require.NoError(t, os.WriteFile(files[len(files)-1], data, 0o644))
+
+			q = openEventQueue(t, dir, 0)
+			defer q.Close()
+
+			assert.Equal(t, 2, q.Len())
+			for idx := range 2 {
+				v, err := q.Take()
+				require.NoError(t, err)
+				assert.Equal(t, idx, v.ID)
+			}
+			_, err = q.Take()
+			assert.ErrorIs(t, err, ErrEmpty)
+		},
+		"Failed offset write does not consume": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 0)
+			defer q.Close()
+
+			for idx := range 2 {
+				require.NoError(t, q.Put(event{ID: idx}))
+			}
+
+			// A read only offset file makes saving the offset fail.
+			offset := q.offset
+			readOnly, err := os.Open(filepath.Join(dir, offsetFile))
+			require.NoError(t, err)
+			q.offset = readOnly
+
+			_, err = q.Take()
+			assert.Error(t, err)
+			assert.Equal(t, 2, q.Len())
+
+			require.NoError(t, readOnly.Close())
+			q.offset = offset
+			for idx := range 2 {
+				v, err := q.Take()
+				require.NoError(t, err)
+				assert.Equal(t, idx, v.ID)
+			}
+		},
+		"Torn offset redelivers the oldest segment": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 0)
+			for idx := range 5 {
+				require.NoError(t, q.Put(event{ID: idx}))
+			}
+			for range 3 {
+				_, err := q.Take()
+				require.NoError(t, err)
+			}
+			require.NoError(t, q.Close())
+
+			require.NoError(t, os.WriteFile(filepath.Join(dir, offsetFile), []byte{1, 2, 3}, 0o644))
+
+			q = openEventQueue(t, dir, 0)
+			defer q.Close()
+
+			assert.Equal(t, 5, q.Len())
+			for idx := range 5 {
+				v, err := q.Take()
+				require.NoError(t, err)
+				assert.Equal(t, idx, v.ID)
+			}
+		},
+		"Corrupted record in active segment": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 0)
+			for idx := range 10 {
+				require.NoError(t, q.Put(event{ID: idx}))
+			}
+			require.NoError(t, q.Close())
+
+			// Flip a payload byte of the second record, which has valid records after it.
+			files := segmentFiles(t, dir)
+			data, err := os.ReadFile(files[0])
+			require.NoError(t, err)
+			first := recordHeaderSize + int(binary.LittleEndian.Uint32(data[0:4]))
+			data[first+recordHeaderSize] ^= 0xff
+			require.NoError(t, os.WriteFile(files[0], data, 0o644))
+
+			_, err = OpenDiskQueue[event](dir, JSONEncoder[event]{}, DiskQueueOptions{})
+			assert.ErrorIs(t, err, ErrCorrupted)
+
+			// Nothing was truncated, so the records can still be salvaged.
+			after, err := os.ReadFile(files[0])
+			require.NoError(t, err)
+			assert.Equal(t, data, after)
+		},
+		"Corrupted sealed segment": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 32)
+			for idx := range 5 {
+				require.NoError(t, q.Put(event{ID: idx}))
+			}
+			require.NoError(t, q.Close())
+
+			files := segmentFiles(t, dir)
+			require.Greater(t, len(files), 1)
+			data, err := os.ReadFile(files[0])
+			require.NoError(t, err)
+			data[len(data)-1] ^= 0xff
+			require.NoError(t, os.WriteFile(files[0], data, 0o644))
+
+			_, err = OpenDiskQueue[event](dir, JSONEncoder[event]{}, DiskQueueOptions{SegmentSize: 32})
+			assert.ErrorIs(t, err, ErrCorrupted)
+		},
+		"Closed queue": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 0)
+			require.NoError(t, q.Close())
+			require.NoError(t, q.Close())
+
+			assert.ErrorIs(t, q.Put(event{}), ErrClosed)
+			_, err := q.Take()
+			assert.ErrorIs(t, err, ErrClosed)
+			_, ok := q.Peek()
+			assert.False(t, ok)
+		},
+		"Peek across segments and restarts": func(t *testing.T, dir string) {
+			q := openEventQueue(t, dir, 64)
+			_, ok := q.Peek()
+			assert.False(t, ok)
+
+			for idx := range 10 {
+				require.NoError(t, q.Put(event{ID: idx, Name: "event"}))
+			}
+
+			for idx := range 5 {
+				peeked, ok := q.Peek()
+				assert.True(t, ok)
+				assert.Equal(t, idx, peeked.ID)
+
+				// Peeking does not consume the element.
+				peeked, _ = q.Peek()
+				assert.Equal(t, idx, peeked.ID)
+
+				val, err := q.Take()
+				require.NoError(t, err)
+				assert.Equal(t, peeked, val)
+			}
+			require.NoError(t, q.Close())
+
+			q = openEventQueue(t, dir, 64)
+			defer q.Close()
+
+			peeked, ok := q.Peek()
+			assert.True(t, ok)
+			assert.Equal(t, 5, peeked.ID)
+			assert.Equal(t, 5, q.Len())
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc(t, t.TempDir())
+		})
+	}
+}
+
+type failingEncoder struct{}
+
+func (failingEncoder) Encode(v int) ([]byte, error) {
+	if v < 0 {
+		return nil, errors.New("negative")
+	}
+	return []byte(strconv.Itoa(v)), nil
+}
+
+func (failingEncoder) Decode(data []byte) (int, error) {
+	return strconv.Atoi(string(data))
+}
+
+func TestDiskQueue_CustomEncoder(t *testing.T) {
+	q, err := OpenDiskQueue[int](t.TempDir(), failingEncoder{}, DiskQueueOptions{})
+	require.NoError(t, err)
+	defer q.Close()
+
+	assert.False(t, q.Enqueue(-1))
+	assert.True(t, q.Enqueue(1))
+	assert.Equal(t, 1, q.Len())
+
+	v, ok := q.Dequeue()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+}
+
+func BenchmarkDiskQueue(b *testing.B) {
+	q, err := OpenDiskQueue[int](b.TempDir(), failingEncoder{}, DiskQueueOptions{SegmentSize: 1 << 20})
+	require.NoError(b, err)
+	defer q.Close()
+
+	b.ResetTimer()
+	for idx := range b.N {
+		q.Enqueue(idx)
+		if v, ok := q.Dequeue(); !ok || v != idx {
+			b.Fail()
+		}
+	}
+}
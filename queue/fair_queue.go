@@ -0,0 +1,228 @@
+package queue
+
+import (
+	"iter"
+	"sync"
+)
+
+var (
+	_ LenQueue[string]  = (*FairQueue[string, string])(nil)
+	_ PeekQueue[string] = (*FairQueue[string, string])(nil)
+)
+
+// KeyFn returns the key an element is grouped by.
+type KeyFn[K comparable, T any] func(T) K
+
+type fairSubQueue[T any] struct {
+	deque *Deque[T]
+
+	// credit is the number of elements the key may still dequeue in the current turn.
+	credit int
+}
+
+// FairQueue is a thread-safe queue that keeps a separate FIFO sub-queue per key
+// and serves the keys using weighted round-robin.
+// A key with weight w dequeues up to w elements per turn,
+// so a single key flooding the queue cannot starve the others.
+type FairQueue[K comparable, T any] struct {
+	mux   *sync.Mutex
+	keyFn KeyFn[K, T]
+
+	queues  map[K]*fairSubQueue[T]
+	weights map[K]int
+
+	// active holds the keys with pending elements in the order they are served.
+	active *Deque[K]
+
+	maxKeySize int
+	len        int
+}
+
+// NewFairQueue creates a queue grouping elements by keyFn.
+// Each key holds at most maxKeySize elements, or any number of elements if maxKeySize is Unbounded.
+func NewFairQueue[K comparable, T any](keyFn KeyFn[K, T], maxKeySize int) *FairQueue[K, T] {
+	return &FairQueue[K, T]{
+		mux:        &sync.Mutex{},
+		keyFn:      keyFn,
+		queues:     make(map[K]*fairSubQueue[T]),
+		weights:    make(map[K]int),
+		active:     NewDeque[K](0),
+		maxKeySize: maxKeySize,
+	}
+}
+
+// SetWeight sets the number of elements key may dequeue per turn.
+// Keys default to a weight of 1 and weights below 1 are treated as 1.
+func (q *FairQueue[K, T]) SetWeight(key K, weight int) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if weight <= 1 {
+		delete(q.weights, key)
+		return
+	}
+
+	q.weights[key] = weight
+}
+
+func (q *FairQueue[K, T]) Len() int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.len
+}
+
+// LenKey returns the number of elements pending for key.
+func (q *FairQueue[K, T]) LenKey(key K) int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	sub, ok := q.queues[key]
+	if !ok {
+		return 0
+	}
+
+	return sub.deque.Len()
+}
+
+// Enqueue adds val to the end of the sub-queue of its key.
+// Returns false if that sub-queue is full.
+func (q *FairQueue[K, T]) Enqueue(val T) bool {
+	key := q.keyFn(val)
+
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	sub, ok := q.queues[key]
+	if !ok {
+		if q.maxKeySize == 0 {
+			return false
+		}
+
+		sub = &fairSubQueue[T]{deque: NewDeque[T](0)}
+		q.queues[key] = sub
+		q.active.PushBack(key)
+	}
+
+	if isFull(sub.deque.Len(), q.maxKeySize) {
+		return false
+	}
+
+	sub.deque.PushBack(val)
+	q.len++
+	return true
+}
+
+// Dequeue removes the next element of the key whose turn it is.
+func (q *FairQueue[K, T]) Dequeue() (T, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	key, ok := q.active.PeekFront()
+	if !ok {
+		var empty T
+		return empty, false
+	}
+
+	sub := q.queues[key]
+	if sub.credit == 0 {
+		sub.credit = q.weight(key)
+	}
+
+	val, _ := sub.deque.PopFront()
+	sub.credit--
+	q.len--
+
+	switch {
+	case sub.deque.Len() == 0:
+		// Forget idle keys so that their state does not accumulate.
+		q.active.PopFront()
+		delete(q.queues, key)
+	case sub.credit == 0:
+		q.active.PopFront()
+		q.active.PushBack(key)
+	}
+
+	return val, true
+}
+
+// Peek returns the element the next Dequeue would return without removing it.
+func (q *FairQueue[K, T]) Peek() (T, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	key, ok := q.active.PeekFront()
+	if !ok {
+		var empty T
+		return empty, false
+	}
+
+	return q.queues[key].deque.PeekFront()
+}
+
+// All returns an iterator over a snapshot of the pending elements in the order Dequeue would return them.
+// The lock is not held while the consumer runs.
+func (q *FairQueue[K, T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		q.mux.Lock()
+		snapshot := q.order()
+		q.mux.Unlock()
+
+		for _, v := range snapshot {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that dequeues elements until the queue is empty.
+func (q *FairQueue[K, T]) Drain() iter.Seq[T] {
+	return drain[T](q)
+}
+
+// order replays the weighted round-robin on cursors over the sub-queues, leaving the queue untouched.
+// The caller must hold q.mux.
+func (q *FairQueue[K, T]) order() []T {
+	type cursor struct {
+		key    K
+		pos    int
+		credit int
+	}
+
+	turns := NewDeque[*cursor](q.active.Len())
+	for key := range q.active.All() {
+		turns.PushBack(&cursor{key: key, credit: q.queues[key].credit})
+	}
+
+	result := make([]T, 0, q.len)
+	for turns.Len() > 0 {
+		c, _ := turns.PeekFront()
+		sub := q.queues[c.key]
+		if c.credit == 0 {
+			c.credit = q.weight(c.key)
+		}
+
+		val, _ := sub.deque.At(c.pos)
+		result = append(result, val)
+		c.pos++
+		c.credit--
+
+		switch {
+		case c.pos == sub.deque.Len():
+			turns.PopFront()
+		case c.credit == 0:
+			turns.PopFront()
+			turns.PushBack(c)
+		}
+	}
+
+	return result
+}
+
+func (q *FairQueue[K, T]) weight(key K) int {
+	if weight, ok := q.weights[key]; ok {
+		return weight
+	}
+
+	return 1
+}
@@ -0,0 +1,155 @@
+package queue
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tenantJob struct {
+	tenant string
+	id     int
+}
+
+func tenantOf(j tenantJob) string {
+	return j.tenant
+}
+
+func dequeueTenants(q *FairQueue[string, tenantJob], n int) string {
+	tenants := make([]string, 0, n)
+	for range n {
+		v, ok := q.Dequeue()
+		if !ok {
+			break
+		}
+		tenants = append(tenants, v.tenant)
+	}
+
+	return strings.Join(tenants, "")
+}
+
+func allTenants(q *FairQueue[string, tenantJob]) string {
+	tenants := make([]string, 0, q.Len())
+	for v := range q.All() {
+		tenants = append(tenants, v.tenant)
+	}
+
+	return strings.Join(tenants, "")
+}
+
+func TestFairQueue(t *testing.T) {
+	tests := map[string]struct {
+		weights  map[string]int
+		enqueue  []string
+		expected string
+	}{
+		"round robin": {
+			enqueue:  []string{"a", "a", "a", "a", "b", "b", "c"},
+			expected: "abcabaa",
+		},
+		"flooding key does not starve others": {
+			enqueue:  append(strings.Split(strings.Repeat("a", 10), ""), "b", "c"),
+			expected: "abcaaaaaaaaa",
+		},
+		"weighted": {
+			weights:  map[string]int{"a": 3, "b": 2},
+			enqueue:  strings.Split("aaaaaabbbbcc", ""),
+			expected: "aaabbcaaabbc",
+		},
+		"weight below one": {
+			weights:  map[string]int{"a": 0},
+			enqueue:  strings.Split("aabb", ""),
+			expected: "abab",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			q := NewFairQueue(tenantOf, Unbounded)
+			for key, weight := range tc.weights {
+				q.SetWeight(key, weight)
+			}
+
+			for idx, tenant := range tc.enqueue {
+				assert.True(t, q.Enqueue(tenantJob{tenant: tenant, id: idx}))
+			}
+			assert.Equal(t, len(tc.enqueue), q.Len())
+			assert.Equal(t, tc.expected, allTenants(q))
+
+			// Partway through a turn, All continues with the remaining credit of the current key.
+			first := dequeueTenants(q, 1)
+			assert.Equal(t, tc.expected[1:], allTenants(q))
+
+			assert.Equal(t, tc.expected, first+dequeueTenants(q, len(tc.enqueue)-1))
+			assert.Equal(t, 0, q.Len())
+			assert.Empty(t, allTenants(q))
+
+			_, ok := q.Dequeue()
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestFairQueue_FIFOPerKey(t *testing.T) {
+	q := NewFairQueue(tenantOf, Unbounded)
+	for idx := range 10 {
+		q.Enqueue(tenantJob{tenant: []string{"a", "b"}[idx%2], id: idx})
+	}
+
+	last := map[string]int{"a": -1, "b": -1}
+	for range 10 {
+		v, ok := q.Dequeue()
+		assert.True(t, ok)
+		assert.Greater(t, v.id, last[v.tenant])
+		last[v.tenant] = v.id
+	}
+}
+
+func TestFairQueue_KeyCapacity(t *testing.T) {
+	q := NewFairQueue(tenantOf, 2)
+	assert.True(t, q.Enqueue(tenantJob{tenant: "a"}))
+	assert.True(t, q.Enqueue(tenantJob{tenant: "a"}))
+	assert.False(t, q.Enqueue(tenantJob{tenant: "a"}))
+	assert.True(t, q.Enqueue(tenantJob{tenant: "b"}))
+
+	assert.Equal(t, 2, q.LenKey("a"))
+	assert.Equal(t, 1, q.LenKey("b"))
+	assert.Equal(t, 0, q.LenKey("c"))
+	assert.Equal(t, 3, q.Len())
+
+	assert.Equal(t, "ab", dequeueTenants(q, 2))
+	assert.True(t, q.Enqueue(tenantJob{tenant: "a"}))
+	assert.Equal(t, 2, q.LenKey("a"))
+	assert.Equal(t, 0, q.LenKey("b"))
+
+	assert.False(t, NewFairQueue(tenantOf, 0).Enqueue(tenantJob{tenant: "a"}))
+}
+
+func TestFairQueue_Concurrent(t *testing.T) {
+	const (
+		tenants   = 8
+		perTenant = 1000
+	)
+
+	q := NewFairQueue(tenantOf, Unbounded)
+	wg := sync.WaitGroup{}
+	wg.Add(tenants)
+	for idx := range tenants {
+		go func() {
+			defer wg.Done()
+			for id := range perTenant {
+				assert.True(t, q.Enqueue(tenantJob{tenant: string(rune('a' + idx)), id: id}))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, tenants*perTenant, q.Len())
+	for range tenants * perTenant {
+		_, ok := q.Dequeue()
+		assert.True(t, ok)
+	}
+	assert.Equal(t, 0, q.Len())
+}
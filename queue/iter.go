@@ -0,0 +1,15 @@
+package queue
+
+import "iter"
+
+// drain yields elements dequeued from q until it is empty or the consumer stops.
+func drain[T any](q Queue[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := q.Dequeue()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
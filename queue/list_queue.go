@@ -1,30 +1,72 @@
 package queue
 
-import "container/list"
+import (
+	"container/list"
+	"iter"
+	"slices"
+)
 
 var (
-	_ Queue[string] = (*StdQueue[string])(nil)
+	_ Queue[string]     = (*StdQueue[string])(nil)
+	_ PeekQueue[string] = (*StdQueue[string])(nil)
 )
 
+// StdQueue is a queue backed by a linked list.
+// It holds at most maxSize elements, or any number of elements if maxSize is Unbounded.
+// It is not safe for concurrent use.
 type StdQueue[T any] struct {
 	list    *list.List
 	maxSize int
+
+	policy  OverflowPolicy
+	onDrop  DropFn[T]
+	dropped uint64
 }
 
 func NewStdQueue[T any](maxSize int) *StdQueue[T] {
 	return &StdQueue[T]{list: list.New(), maxSize: maxSize}
 }
 
+// WithOverflowPolicy sets what happens when an element is enqueued while the queue is full.
+// onDrop is optional and is called with every discarded element.
+// As StdQueue is not safe for concurrent use, Block behaves like Reject.
+func (s *StdQueue[T]) WithOverflowPolicy(policy OverflowPolicy, onDrop DropFn[T]) *StdQueue[T] {
+	s.policy = policy
+	s.onDrop = onDrop
+	return s
+}
+
+// Dropped returns the number of elements discarded by the overflow policy.
+func (s *StdQueue[T]) Dropped() uint64 {
+	return s.dropped
+}
+
 func (s *StdQueue[T]) Len() int {
 	return s.list.Len()
 }
 
 func (s *StdQueue[T]) Enqueue(val T) bool {
-	if s.Len() >= s.maxSize {
+	if !isFull(s.Len(), s.maxSize) {
+		s.list.PushBack(val)
+		return true
+	}
+
+	dropped := val
+	switch s.policy {
+	case DropOldest:
+		if front := s.list.Front(); front != nil {
+			dropped = s.list.Remove(front).(T)
+			s.list.PushBack(val)
+		}
+	case DropNewest:
+	default:
 		return false
 	}
 
-	s.list.PushBack(val)
+	s.dropped++
+	if s.onDrop != nil {
+		s.onDrop(dropped)
+	}
 	return true
 }
 
@@ -37,3 +79,35 @@ func (s *StdQueue[T]) Dequeue() (T, bool) {
 
 	return s.list.Remove(v).(T), true
 }
+
+func (s *StdQueue[T]) Peek() (T, bool) {
+	v := s.list.Front()
+	if v == nil {
+		var empty T
+		return empty, false
+	}
+
+	return v.Value.(T), true
+}
+
+// Snapshot returns a copy of the elements from front to back.
+func (s *StdQueue[T]) Snapshot() []T {
+	return slices.AppendSeq(make([]T, 0, s.list.Len()), s.All())
+}
+
+// All returns an iterator over the elements from front to back without removing them.
+// The queue must not be modified while iterating.
+func (s *StdQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := s.list.Front(); e != nil; e = e.Next() {
+			if !yield(e.Value.(T)) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that dequeues elements until the queue is empty.
+func (s *StdQueue[T]) Drain() iter.Seq[T] {
+	return drain[T](s)
+}
@@ -1,71 +1,170 @@
 package queue
 
 import (
+	"iter"
+	"slices"
 	"sync"
 )
 
 var (
-	_ Queue[string] = (*LockQueue[string])(nil)
+	_ Queue[string]     = (*LockQueue[string])(nil)
+	_ PeekQueue[string] = (*LockQueue[string])(nil)
 )
 
+// LockQueue is a thread-safe queue backed by a ring buffer.
+// A bounded queue holds at most len elements while an Unbounded queue
+// doubles its buffer when full and halves it once it is mostly empty.
 type LockQueue[T any] struct {
-	buffer  []T
+	deque   *Deque[T]
 	mux     *sync.Mutex
-	start   int
-	end     int
+	notFull *sync.Cond
 	maxSize int
+
+	policy  OverflowPolicy
+	onDrop  DropFn[T]
+	dropped uint64
 }
 
 func NewLockQueue[T any](len int) *LockQueue[T] {
+	mux := &sync.Mutex{}
 	return &LockQueue[T]{
-		buffer:  make([]T, len+1),
-		mux:     &sync.Mutex{},
-		start:   0,
-		end:     0,
+		deque:   NewDeque[T](max(len, 0)),
+		mux:     mux,
+		notFull: sync.NewCond(mux),
 		maxSize: len,
 	}
 }
 
-func (q *LockQueue[T]) Len() int {
+// WithOverflowPolicy sets what happens when an element is enqueued while the queue is full.
+// onDrop is optional and is called outside of the lock with every discarded element.
+func (q *LockQueue[T]) WithOverflowPolicy(policy OverflowPolicy, onDrop DropFn[T]) *LockQueue[T] {
 	q.mux.Lock()
-	diff := q.end - q.start
-	q.mux.Unlock()
-	if diff < 0 {
-		return diff + q.maxSize + 1
-	}
+	defer q.mux.Unlock()
+
+	q.policy = policy
+	q.onDrop = onDrop
+	q.notFull.Broadcast()
+	return q
+}
 
-	return diff
+// Dropped returns the number of elements discarded by the overflow policy.
+func (q *LockQueue[T]) Dropped() uint64 {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.dropped
+}
+
+func (q *LockQueue[T]) Len() int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.deque.Len()
 }
 
 func (q *LockQueue[T]) Enqueue(val T) bool {
-	if q.Len() == q.maxSize {
-		return false
+	q.mux.Lock()
+
+	// A queue without any room can never be unblocked.
+	for q.policy == Block && q.maxSize > 0 && isFull(q.deque.Len(), q.maxSize) {
+		q.notFull.Wait()
 	}
 
-	q.mux.Lock()
-	q.buffer[q.end] = val
-	q.end++
-	if q.end >= q.maxSize+1 {
-		q.end = 0
+	if !isFull(q.deque.Len(), q.maxSize) {
+		q.deque.PushBack(val)
+		q.mux.Unlock()
+		return true
 	}
+
+	dropped := val
+	switch q.policy {
+	case DropOldest:
+		if oldest, ok := q.deque.PopFront(); ok {
+			dropped = oldest
+			q.deque.PushBack(val)
+		}
+	case DropNewest:
+	default:
+		q.mux.Unlock()
+		return false
+	}
+
+	q.dropped++
+	onDrop := q.onDrop
 	q.mux.Unlock()
 
+	if onDrop != nil {
+		onDrop(dropped)
+	}
 	return true
 }
 
 func (q *LockQueue[T]) Dequeue() (T, bool) {
-	if q.Len() == 0 {
-		var empty T
-		return empty, false
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	v, ok := q.deque.PopFront()
+	if ok {
+		q.notFull.Signal()
 	}
 
+	return v, ok
+}
+
+func (q *LockQueue[T]) Peek() (T, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.deque.PeekFront()
+}
+
+// Snapshot returns a copy of the elements from front to back.
+func (q *LockQueue[T]) Snapshot() []T {
 	q.mux.Lock()
-	v := q.buffer[q.start]
-	q.start++
-	if q.start >= q.maxSize+1 {
-		q.start = 0
+	defer q.mux.Unlock()
+	return slices.AppendSeq(make([]T, 0, q.deque.Len()), q.deque.All())
+}
+
+// EnqueueAll adds as many elements of vals as fit to the end of the queue under a single lock.
+// Returns the number of elements added, which are always a prefix of vals.
+// The overflow policy does not apply, so no element is dropped and the call never blocks.
+func (q *LockQueue[T]) EnqueueAll(vals []T) int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	n := len(vals)
+	if q.maxSize >= 0 {
+		n = min(n, q.maxSize-q.deque.Len())
 	}
-	q.mux.Unlock()
 
-	return v, true
+	q.deque.pushBackAll(vals[:n])
+	return n
+}
+
+// DequeueN removes up to len(dst) elements from the front of the queue into dst under a single lock.
+// Returns the number of elements removed.
+func (q *LockQueue[T]) DequeueN(dst []T) int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	n := q.deque.popFrontInto(dst)
+	if n > 0 {
+		q.notFull.Broadcast()
+	}
+
+	return n
+}
+
+// All returns an iterator over a snapshot of the elements from front to back.
+// The lock is not held while the consumer runs.
+func (q *LockQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range q.Snapshot() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that dequeues elements until the queue is empty.
+func (q *LockQueue[T]) Drain() iter.Seq[T] {
+	return drain[T](q)
 }
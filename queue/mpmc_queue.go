@@ -0,0 +1,130 @@
+package queue
+
+import "sync/atomic"
+
+var (
+	_ LenQueue[string] = (*MPMCQueue[string])(nil)
+)
+
+// cacheLineSize is used to pad hot fields so that they do not share a cache line.
+const cacheLineSize = 64
+
+type mpmcSlot[T any] struct {
+	// seq is twice the position the slot is ready for, plus one if it holds a value.
+	// It equals 2*pos when the slot is free to be written at pos
+	// and 2*pos+1 once it holds the value written at pos.
+	// Doubling keeps a full slot distinct from a slot freed for the next lap, even with a single slot.
+	seq atomic.Uint64
+	val T
+}
+
+// MPMCQueue is a bounded lock-free queue that is safe for multiple producers and consumers.
+// It is a ring buffer where each slot carries a sequence number that producers and consumers
+// use to claim it, so no operation ever takes a lock.
+//
+// MPMCQueue does not implement PeekQueue.
+// Reading the front slot without claiming it would race with a consumer freeing the slot
+// and a producer refilling it, and making that read safe for any T would cost an allocation per element.
+// Use LockQueue when the front of the queue has to be inspected.
+type MPMCQueue[T any] struct {
+	_    [cacheLineSize]byte
+	head atomic.Uint64
+	_    [cacheLineSize - 8]byte
+	tail atomic.Uint64
+	_    [cacheLineSize - 8]byte
+
+	buffer  []mpmcSlot[T]
+	maxSize uint64
+}
+
+func NewMPMCQueue[T any](len int) *MPMCQueue[T] {
+	len = max(len, 0)
+	q := &MPMCQueue[T]{
+		buffer:  make([]mpmcSlot[T], len),
+		maxSize: uint64(len),
+	}
+
+	for idx := range q.buffer {
+		q.buffer[idx].seq.Store(2 * uint64(idx))
+	}
+
+	return q
+}
+
+// Len returns the number of elements in the queue.
+// The result is only a snapshot when other goroutines are using the queue.
+func (q *MPMCQueue[T]) Len() int {
+	for {
+		tail := q.tail.Load()
+		head := q.head.Load()
+		if q.tail.Load() != tail {
+			continue
+		}
+
+		if head >= tail {
+			return 0
+		}
+
+		return int(min(tail-head, q.maxSize))
+	}
+}
+
+func (q *MPMCQueue[T]) Enqueue(val T) bool {
+	if q.maxSize == 0 {
+		return false
+	}
+
+	pos := q.tail.Load()
+	for {
+		slot := &q.buffer[pos%q.maxSize]
+		seq := slot.seq.Load()
+
+		switch diff := int64(seq - 2*pos); {
+		case diff == 0:
+			if !q.tail.CompareAndSwap(pos, pos+1) {
+				pos = q.tail.Load()
+				continue
+			}
+
+			slot.val = val
+			slot.seq.Store(2*pos + 1)
+			return true
+		case diff < 0:
+			// The slot still holds the value from the previous lap, so the queue is full.
+			return false
+		default:
+			pos = q.tail.Load()
+		}
+	}
+}
+
+func (q *MPMCQueue[T]) Dequeue() (T, bool) {
+	var empty T
+	if q.maxSize == 0 {
+		return empty, false
+	}
+
+	pos := q.head.Load()
+	for {
+		slot := &q.buffer[pos%q.maxSize]
+		seq := slot.seq.Load()
+
+		switch diff := int64(seq - (2*pos + 1)); {
+		case diff == 0:
+			if !q.head.CompareAndSwap(pos, pos+1) {
+				pos = q.head.Load()
+				continue
+			}
+
+			val := slot.val
+			slot.val = empty
+			slot.seq.Store(2 * (pos + q.maxSize))
+			return val, true
+		case diff < 0:
+			// The slot has not been written in this lap, so the queue is empty.
+			return empty, false
+		default:
+			pos = q.head.Load()
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package queue
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMPMCQueue_ZeroSize(t *testing.T) {
+	q := NewMPMCQueue[int](0)
+	assert.False(t, q.Enqueue(1))
+	_, ok := q.Dequeue()
+	assert.False(t, ok)
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestMPMCQueue_SingleSlot(t *testing.T) {
+	q := NewMPMCQueue[int](1)
+	for idx := range 3 {
+		assert.True(t, q.Enqueue(idx))
+		assert.False(t, q.Enqueue(idx+1))
+		assert.Equal(t, 1, q.Len())
+
+		v, ok := q.Dequeue()
+		assert.True(t, ok)
+		assert.Equal(t, idx, v)
+
+		_, ok = q.Dequeue()
+		assert.False(t, ok)
+		assert.Equal(t, 0, q.Len())
+	}
+}
+
+func TestMPMCQueue_Stress(t *testing.T) {
+	tests := map[string]struct {
+		producers int
+		consumers int
+		size      int
+	}{
+		"1 producer 1 consumer":  {producers: 1, consumers: 1, size: 4},
+		"4 producers 1 consumer": {producers: 4, consumers: 1, size: 8},
+		"1 producer 4 consumers": {producers: 1, consumers: 4, size: 8},
+		"8 producers 8 consumers": {
+			producers: 8, consumers: 8, size: 3,
+		},
+		"4 producers 4 consumers single slot": {
+			producers: 4, consumers: 4, size: 1,
+		},
+	}
+
+	const perProducer = 5000
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			q := NewMPMCQueue[int](tc.size)
+			total := tc.producers * perProducer
+			seen := make([]atomic.Int32, total)
+			consumed := atomic.Int64{}
+
+			wg := sync.WaitGroup{}
+			wg.Add(tc.producers + tc.consumers)
+			for p := range tc.producers {
+				go func() {
+					defer wg.Done()
+					for idx := range perProducer {
+						for !q.Enqueue(p*perProducer + idx) {
+							runtime.Gosched()
+						}
+					}
+				}()
+			}
+
+			for range tc.consumers {
+				go func() {
+					defer wg.Done()
+
+					// lastSeen tracks per producer ordering, which must be preserved for a single consumer.
+					lastSeen := make([]int, tc.producers)
+					for idx := range lastSeen {
+						lastSeen[idx] = -1
+					}
+
+					for consumed.Load() < int64(total) {
+						v, ok := q.Dequeue()
+						if !ok {
+							runtime.Gosched()
+							continue
+						}
+
+						consumed.Add(1)
+						seen[v].Add(1)
+
+						producer, idx := v/perProducer, v%perProducer
+						assert.Greater(t, idx, lastSeen[producer])
+						lastSeen[producer] = idx
+						assert.LessOrEqual(t, q.Len(), tc.size)
+					}
+				}()
+			}
+			wg.Wait()
+
+			for v := range seen {
+				assert.Equal(t, int32(1), seen[v].Load(), "value %d", v)
+			}
+			assert.Equal(t, 0, q.Len())
+		})
+	}
+}
+
+// mutexQueue guards a StdQueue with a mutex so that it can be benchmarked under contention.
+type mutexQueue[T any] struct {
+	mux   sync.Mutex
+	queue *StdQueue[T]
+}
+
+func (q *mutexQueue[T]) Enqueue(val T) bool {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.queue.Enqueue(val)
+}
+
+func (q *mutexQueue[T]) Dequeue() (T, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.queue.Dequeue()
+}
+
+func BenchmarkContendedQueues(b *testing.B) {
+	const size = 1024
+	queues := map[string]func() Queue[int]{
+		"LockQueue":      qWrap(NewLockQueue[int], size),
+		"StdQueue+Mutex": func() Queue[int] { return &mutexQueue[int]{queue: NewStdQueue[int](size)} },
+		"MPMCQueue":      qWrap(NewMPMCQueue[int], size),
+		"BlockingQueue":  qWrap(NewBlockingQueue[int], size),
+	}
+
+	for name, fn := range queues {
+		for _, goroutines := range []int{1, 2, 4, 8, 16, 32, 64} {
+			b.Run(name+"/"+strconv.Itoa(goroutines), func(b *testing.B) {
+				b.ReportAllocs()
+				q := fn()
+				per := b.N/goroutines + 1
+
+				wg := sync.WaitGroup{}
+				wg.Add(goroutines)
+				b.ResetTimer()
+				for range goroutines {
+					go func() {
+						defer wg.Done()
+						for idx := range per {
+							for !q.Enqueue(idx) {
+								runtime.Gosched()
+							}
+							for {
+								if _, ok := q.Dequeue(); ok {
+									break
+								}
+								runtime.Gosched()
+							}
+						}
+					}()
+				}
+				wg.Wait()
+			})
+		}
+	}
+}
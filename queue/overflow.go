@@ -0,0 +1,22 @@
+package queue
+
+// OverflowPolicy decides what a bounded queue does when an element is enqueued while it is full.
+type OverflowPolicy int
+
+const (
+	// Reject refuses the new element and Enqueue returns false.
+	// This is the default policy.
+	Reject OverflowPolicy = iota
+
+	// DropOldest evicts the element at the front of the queue to make room for the new element.
+	DropOldest
+
+	// DropNewest discards the new element while Enqueue still returns true.
+	DropNewest
+
+	// Block waits until another goroutine makes room for the new element.
+	Block
+)
+
+// DropFn is called with every element discarded by an overflow policy.
+type DropFn[T any] func(T)
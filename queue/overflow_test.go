@@ -0,0 +1,162 @@
+package queue
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type overflowQueue[T any] interface {
+	LenQueue[T]
+	Dropped() uint64
+}
+
+func TestOverflowPolicies(t *testing.T) {
+	queues := map[string]func(size int, policy OverflowPolicy, onDrop DropFn[int]) overflowQueue[int]{
+		"LockQueue": func(size int, policy OverflowPolicy, onDrop DropFn[int]) overflowQueue[int] {
+			return NewLockQueue[int](size).WithOverflowPolicy(policy, onDrop)
+		},
+		"StdQueue": func(size int, policy OverflowPolicy, onDrop DropFn[int]) overflowQueue[int] {
+			return NewStdQueue[int](size).WithOverflowPolicy(policy, onDrop)
+		},
+	}
+
+	tests := map[string]struct {
+		size     int
+		policy   OverflowPolicy
+		accepted []bool
+		dropped  []int
+		expected []int
+	}{
+		"reject": {
+			size:     3,
+			policy:   Reject,
+			accepted: []bool{true, true, true, false, false},
+			dropped:  []int{},
+			expected: []int{0, 1, 2},
+		},
+		"drop oldest": {
+			size:     3,
+			policy:   DropOldest,
+			accepted: []bool{true, true, true, true, true},
+			dropped:  []int{0, 1},
+			expected: []int{2, 3, 4},
+		},
+		"drop newest": {
+			size:     3,
+			policy:   DropNewest,
+			accepted: []bool{true, true, true, true, true},
+			dropped:  []int{3, 4},
+			expected: []int{0, 1, 2},
+		},
+		"drop oldest without room": {
+			size:     0,
+			policy:   DropOldest,
+			accepted: []bool{true, true},
+			dropped:  []int{0, 1},
+			expected: []int{},
+		},
+		"block without room": {
+			size:     0,
+			policy:   Block,
+			accepted: []bool{false},
+			dropped:  []int{},
+			expected: []int{},
+		},
+	}
+
+	for name, fn := range queues {
+		t.Run(name, func(t *testing.T) {
+			for name, tc := range tests {
+				t.Run(name, func(t *testing.T) {
+					dropped := make([]int, 0)
+					q := fn(tc.size, tc.policy, func(v int) { dropped = append(dropped, v) })
+
+					for idx, accepted := range tc.accepted {
+						assert.Equal(t, accepted, q.Enqueue(idx))
+						assert.LessOrEqual(t, q.Len(), tc.size)
+					}
+
+					assert.Equal(t, tc.dropped, dropped)
+					assert.Equal(t, uint64(len(tc.dropped)), q.Dropped())
+
+					result := make([]int, 0)
+					for {
+						v, ok := q.Dequeue()
+						if !ok {
+							break
+						}
+						result = append(result, v)
+					}
+					assert.Equal(t, tc.expected, result)
+				})
+			}
+		})
+	}
+}
+
+func TestOverflowPolicies_NilCallback(t *testing.T) {
+	q := NewLockQueue[int](1).WithOverflowPolicy(DropOldest, nil)
+	assert.True(t, q.Enqueue(1))
+	assert.True(t, q.Enqueue(2))
+	assert.Equal(t, uint64(1), q.Dropped())
+	assert.Equal(t, []int{2}, slices.Collect(q.All()))
+}
+
+func TestLockQueue_Block(t *testing.T) {
+	q := NewLockQueue[int](defaultQSize).WithOverflowPolicy(Block, nil)
+	for idx := range defaultQSize {
+		assert.True(t, q.Enqueue(idx))
+	}
+
+	done := make(chan bool)
+	go func() { done <- q.Enqueue(defaultQSize) }()
+
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("enqueue should block while the queue is full")
+	default:
+	}
+
+	v, ok := q.Dequeue()
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+	assert.True(t, <-done)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, slices.Collect(q.All()))
+	assert.Equal(t, uint64(0), q.Dropped())
+}
+
+func TestLockQueue_BlockConcurrent(t *testing.T) {
+	const (
+		producers   = 4
+		perProducer = 1000
+	)
+
+	q := NewLockQueue[int](2).WithOverflowPolicy(Block, nil)
+	wg := sync.WaitGroup{}
+	wg.Add(producers)
+	for range producers {
+		go func() {
+			defer wg.Done()
+			for idx := range perProducer {
+				assert.True(t, q.Enqueue(idx))
+			}
+		}()
+	}
+
+	for range producers * perProducer {
+		for {
+			if _, ok := q.Dequeue(); ok {
+				break
+			}
+			runtime.Gosched()
+		}
+	}
+	wg.Wait()
+	assert.Equal(t, 0, q.Len())
+}
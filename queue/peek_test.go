@@ -0,0 +1,174 @@
+package queue
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueuePeek(t *testing.T) {
+	queues := map[string]func() PeekQueue[string]{
+		"LockQueue":     qpWrap(NewLockQueue[string], defaultQSize),
+		"StdQueue":      qpWrap(NewStdQueue[string], defaultQSize),
+		"BlockingQueue": qpWrap(NewBlockingQueue[string], defaultQSize),
+		"SPSCQueue":     qpWrap(NewSPSCQueue[string], defaultQSize),
+		"Deque":         qpWrap(NewDeque[string], defaultQSize),
+	}
+
+	for name, fn := range queues {
+		t.Run(name, func(t *testing.T) {
+			testPeekCond(t, fn())
+		})
+	}
+}
+
+func testPeekCond(t *testing.T, q PeekQueue[string]) {
+	_, ok := q.Peek()
+	assert.False(t, ok)
+
+	for idx := range defaultQSize {
+		assert.True(t, q.Enqueue("test"+strconv.FormatInt(int64(idx), 10)))
+
+		item, ok := q.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, "test0", item)
+	}
+
+	for idx := range defaultQSize {
+		peeked, ok := q.Peek()
+		assert.True(t, ok)
+
+		item, _ := q.Dequeue()
+		assert.Equal(t, "test"+strconv.FormatInt(int64(idx), 10), peeked)
+		assert.Equal(t, item, peeked)
+	}
+
+	_, ok = q.Peek()
+	assert.False(t, ok)
+}
+
+func TestPeek(t *testing.T) {
+	tests := map[string]func(t *testing.T){
+		"Deque peeks the front for both queue and stack use": func(t *testing.T) {
+			d := NewDeque[int](0)
+			_, ok := d.Peek()
+			assert.False(t, ok)
+
+			d.Enqueue(1)
+			d.Enqueue(2)
+			v, _ := d.Peek()
+			assert.Equal(t, 1, v)
+
+			d.Push(0)
+			v, _ = d.Peek()
+			assert.Equal(t, 0, v)
+			assert.Equal(t, 3, d.Len())
+		},
+		"FairQueue peeks the element of the key whose turn it is": func(t *testing.T) {
+			q := NewFairQueue(func(v string) byte { return v[0] }, Unbounded)
+			q.SetWeight('a', 2)
+			for _, v := range []string{"a1", "a2", "a3", "b1", "b2"} {
+				q.Enqueue(v)
+			}
+
+			for range 5 {
+				peeked, ok := q.Peek()
+				assert.True(t, ok)
+
+				val, _ := q.Dequeue()
+				assert.Equal(t, val, peeked)
+			}
+
+			_, ok := q.Peek()
+			assert.False(t, ok)
+		},
+		"DedupQueue peeks the merged element": func(t *testing.T) {
+			q := NewDedupQueue(func(v string) byte { return v[0] }, Unbounded).
+				WithMerge(func(pending, incoming string) string { return pending + incoming })
+			_, ok := q.Peek()
+			assert.False(t, ok)
+
+			q.Enqueue("a")
+			q.Enqueue("b")
+			q.Enqueue("a")
+			v, ok := q.Peek()
+			assert.True(t, ok)
+			assert.Equal(t, "aa", v)
+			assert.Equal(t, 2, q.Len())
+		},
+		"Zero size queues": func(t *testing.T) {
+			for _, q := range []PeekQueue[int]{NewSPSCQueue[int](0), NewLockQueue[int](0), NewStdQueue[int](0)} {
+				_, ok := q.Peek()
+				assert.False(t, ok)
+			}
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, tc)
+	}
+}
+
+type snapshotQueue[T any] interface {
+	PeekQueue[T]
+	Snapshot() []T
+}
+
+func TestSnapshot(t *testing.T) {
+	queues := map[string]func() snapshotQueue[string]{
+		"LockQueue": func() snapshotQueue[string] { return NewLockQueue[string](defaultQSize) },
+		"StdQueue":  func() snapshotQueue[string] { return NewStdQueue[string](defaultQSize) },
+	}
+
+	for name, fn := range queues {
+		t.Run(name, func(t *testing.T) {
+			q := fn()
+			assert.Empty(t, q.Snapshot())
+
+			for idx := range defaultQSize {
+				q.Enqueue(strconv.Itoa(idx))
+			}
+			q.Dequeue()
+			q.Enqueue("5")
+
+			snapshot := q.Snapshot()
+			assert.Equal(t, []string{"1", "2", "3", "4", "5"}, snapshot)
+
+			// The snapshot is a copy that is unaffected by later changes.
+			snapshot[0] = "changed"
+			q.Dequeue()
+			assert.Equal(t, []string{"2", "3", "4", "5"}, q.Snapshot())
+			assert.Equal(t, "changed", snapshot[0])
+		})
+	}
+}
+
+func TestLockQueue_PeekConcurrent(t *testing.T) {
+	const producers, perProducer = 4, 1000
+	q := NewLockQueue[int](Unbounded)
+
+	wg := sync.WaitGroup{}
+	wg.Add(producers + 1)
+	for range producers {
+		go func() {
+			defer wg.Done()
+			for idx := range perProducer {
+				q.Enqueue(idx)
+			}
+		}()
+	}
+
+	// Peeking never consumes elements, so every enqueued element is still there afterwards.
+	go func() {
+		defer wg.Done()
+		for range producers * perProducer {
+			q.Peek()
+			q.Snapshot()
+		}
+	}()
+
+	wg.Wait()
+	assert.Equal(t, producers*perProducer, q.Len())
+}
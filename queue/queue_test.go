@@ -1,6 +1,8 @@
 package queue
 
 import (
+	"iter"
+	"slices"
 	"strconv"
 	"sync"
 	"testing"
@@ -20,10 +22,17 @@ func qWrap[T any, Q Queue[T]](fn func(int) Q, size int) func() Queue[T] {
 	return func() Queue[T] { return fn(size) }
 }
 
+func qpWrap[T any, Q PeekQueue[T]](fn func(int) Q, size int) func() PeekQueue[T] {
+	return func() PeekQueue[T] { return fn(size) }
+}
+
 func TestQueueCorrectness(t *testing.T) {
 	queues := map[string]func() LenQueue[string]{
-		"LockQueue": qlWrap(NewLockQueue[string], defaultQSize),
-		"StdQueue":  qlWrap(NewStdQueue[string], defaultQSize),
+		"LockQueue":     qlWrap(NewLockQueue[string], defaultQSize),
+		"StdQueue":      qlWrap(NewStdQueue[string], defaultQSize),
+		"BlockingQueue": qlWrap(NewBlockingQueue[string], defaultQSize),
+		"MPMCQueue":     qlWrap(NewMPMCQueue[string], defaultQSize),
+		"SPSCQueue":     qlWrap(NewSPSCQueue[string], defaultQSize),
 	}
 
 	tests := map[string]testFn[string]{
@@ -119,8 +128,12 @@ func testRaceCond(t *testing.T, q LenQueue[string]) {
 
 func BenchmarkSequentialQueues(b *testing.B) {
 	queues := map[string]func() Queue[int]{
-		"LockQueue": qWrap(NewLockQueue[int], defaultQSize),
-		"StdQueue":  qWrap(NewStdQueue[int], defaultQSize),
+		"LockQueue":     qWrap(NewLockQueue[int], defaultQSize),
+		"StdQueue":      qWrap(NewStdQueue[int], defaultQSize),
+		"BlockingQueue": qWrap(NewBlockingQueue[int], defaultQSize),
+		"MPMCQueue":     qWrap(NewMPMCQueue[int], defaultQSize),
+		"SPSCQueue":     qWrap(NewSPSCQueue[int], defaultQSize),
+		"Deque":         qWrap(NewDeque[int], defaultQSize),
 	}
 
 	for name, fn := range queues {
@@ -139,7 +152,10 @@ func BenchmarkSequentialQueues(b *testing.B) {
 
 func TestQueueParallel(t *testing.T) {
 	queues := map[string]func() LenQueue[string]{
-		"LockQueue": qlWrap(NewLockQueue[string], defaultQSize),
+		"LockQueue":     qlWrap(NewLockQueue[string], defaultQSize),
+		"BlockingQueue": qlWrap(NewBlockingQueue[string], defaultQSize),
+		"MPMCQueue":     qlWrap(NewMPMCQueue[string], defaultQSize),
+		"SPSCQueue":     qlWrap(NewSPSCQueue[string], defaultQSize),
 	}
 
 	tests := map[string]testFn[string]{
@@ -159,7 +175,9 @@ func TestQueueParallel(t *testing.T) {
 
 func BenchmarkParallelQueues(b *testing.B) {
 	queues := map[string]func() Queue[int]{
-		"LockQueue": qWrap(NewLockQueue[int], defaultQSize),
+		"LockQueue":     qWrap(NewLockQueue[int], defaultQSize),
+		"BlockingQueue": qWrap(NewBlockingQueue[int], defaultQSize),
+		"MPMCQueue":     qWrap(NewMPMCQueue[int], defaultQSize),
 	}
 
 	for name, fn := range queues {
@@ -178,3 +196,129 @@ func BenchmarkParallelQueues(b *testing.B) {
 		})
 	}
 }
+
+type iterQueue[T any] interface {
+	LenQueue[T]
+	All() iter.Seq[T]
+	Drain() iter.Seq[T]
+}
+
+func TestQueueIterators(t *testing.T) {
+	queues := map[string]func() iterQueue[int]{
+		"LockQueue":     func() iterQueue[int] { return NewLockQueue[int](defaultQSize) },
+		"StdQueue":      func() iterQueue[int] { return NewStdQueue[int](defaultQSize) },
+		"BlockingQueue": func() iterQueue[int] { return NewBlockingQueue[int](defaultQSize) },
+		"SPSCQueue":     func() iterQueue[int] { return NewSPSCQueue[int](defaultQSize) },
+		"DedupQueue":    func() iterQueue[int] { return NewDedupQueue(func(v int) int { return v }, defaultQSize) },
+		"FairQueue":     func() iterQueue[int] { return NewFairQueue(func(int) int { return 0 }, defaultQSize) },
+	}
+
+	tests := map[string]func(t *testing.T, q iterQueue[int]){
+		"All is non destructive": func(t *testing.T, q iterQueue[int]) {
+			assert.Empty(t, slices.Collect(q.All()))
+
+			// Move the front to position 6 so that a full queue crosses the end of both
+			// the 8 slot deque behind LockQueue and the 5 slot ring of SPSCQueue.
+			for idx := range 6 {
+				assert.True(t, q.Enqueue(idx))
+				_, ok := q.Dequeue()
+				assert.True(t, ok)
+			}
+			for idx := range defaultQSize {
+				assert.True(t, q.Enqueue(6+idx))
+			}
+
+			if lq, ok := q.(*LockQueue[int]); ok {
+				assert.Greater(t, lq.deque.head+lq.deque.Len(), len(lq.deque.buffer))
+			}
+
+			expected := []int{6, 7, 8, 9, 10}
+			assert.Equal(t, expected, slices.Collect(q.All()))
+			assert.Equal(t, expected, slices.Collect(q.All()))
+			assert.Equal(t, defaultQSize, q.Len())
+		},
+		"Drain empties the queue": func(t *testing.T, q iterQueue[int]) {
+			for idx := range defaultQSize {
+				assert.True(t, q.Enqueue(idx))
+			}
+
+			for v := range q.Drain() {
+				if v == 1 {
+					break
+				}
+			}
+			assert.Equal(t, []int{2, 3, 4}, slices.Collect(q.Drain()))
+			assert.Equal(t, 0, q.Len())
+		},
+	}
+
+	for name, fn := range queues {
+		t.Run(name, func(t *testing.T) {
+			for name, tc := range tests {
+				t.Run(name, func(t *testing.T) {
+					tc(t, fn())
+				})
+			}
+		})
+	}
+}
+
+func TestUnboundedQueues(t *testing.T) {
+	queues := map[string]func() LenQueue[int]{
+		"LockQueue":     qlWrap(NewLockQueue[int], Unbounded),
+		"StdQueue":      qlWrap(NewStdQueue[int], Unbounded),
+		"BlockingQueue": qlWrap(NewBlockingQueue[int], Unbounded),
+	}
+
+	const count = 10000
+	for name, fn := range queues {
+		t.Run(name, func(t *testing.T) {
+			q := fn()
+			for idx := range count {
+				assert.True(t, q.Enqueue(idx))
+			}
+			assert.Equal(t, count, q.Len())
+
+			for idx := range count {
+				v, ok := q.Dequeue()
+				assert.True(t, ok)
+				assert.Equal(t, idx, v)
+			}
+
+			_, ok := q.Dequeue()
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestZeroSizeQueues(t *testing.T) {
+	queues := map[string]func() LenQueue[int]{
+		"LockQueue":     qlWrap(NewLockQueue[int], 0),
+		"StdQueue":      qlWrap(NewStdQueue[int], 0),
+		"BlockingQueue": qlWrap(NewBlockingQueue[int], 0),
+	}
+
+	for name, fn := range queues {
+		t.Run(name, func(t *testing.T) {
+			q := fn()
+			assert.False(t, q.Enqueue(1))
+			assert.Equal(t, 0, q.Len())
+		})
+	}
+}
+
+func TestLockQueue_GrowAndShrink(t *testing.T) {
+	q := NewLockQueue[int](Unbounded)
+	initial := len(q.deque.buffer)
+
+	for idx := range 1000 {
+		assert.True(t, q.Enqueue(idx))
+	}
+	assert.GreaterOrEqual(t, len(q.deque.buffer), 1000)
+
+	for range 1000 {
+		_, ok := q.Dequeue()
+		assert.True(t, ok)
+	}
+	assert.Equal(t, initial, len(q.deque.buffer))
+}
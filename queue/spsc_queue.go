@@ -0,0 +1,152 @@
+package queue
+
+import (
+	"iter"
+	"sync/atomic"
+)
+
+var (
+	_ LenQueue[string]  = (*SPSCQueue[string])(nil)
+	_ PeekQueue[string] = (*SPSCQueue[string])(nil)
+)
+
+// SPSCQueue is a bounded wait-free queue for exactly one producer goroutine and one consumer goroutine.
+// Enqueue and EnqueueN must only be called by the producer, Dequeue, DequeueN, Peek, All and Drain only by the consumer.
+type SPSCQueue[T any] struct {
+	_ [cacheLineSize]byte
+
+	// head is written by the consumer, cachedTail is the consumer's last view of tail.
+	head       atomic.Uint64
+	cachedTail uint64
+	_          [cacheLineSize - 16]byte
+
+	// tail is written by the producer, cachedHead is the producer's last view of head.
+	tail       atomic.Uint64
+	cachedHead uint64
+	_          [cacheLineSize - 16]byte
+
+	buffer  []T
+	maxSize uint64
+}
+
+func NewSPSCQueue[T any](len int) *SPSCQueue[T] {
+	len = max(len, 0)
+	return &SPSCQueue[T]{
+		buffer:  make([]T, len),
+		maxSize: uint64(len),
+	}
+}
+
+// Len returns the number of elements in the queue.
+// The result is only a snapshot when the producer and consumer are running.
+func (q *SPSCQueue[T]) Len() int {
+	head := q.head.Load()
+	tail := q.tail.Load()
+	if head >= tail {
+		return 0
+	}
+
+	return int(tail - head)
+}
+
+func (q *SPSCQueue[T]) Enqueue(val T) bool {
+	tail := q.tail.Load()
+	if tail-q.cachedHead >= q.maxSize {
+		q.cachedHead = q.head.Load()
+		if tail-q.cachedHead >= q.maxSize {
+			return false
+		}
+	}
+
+	q.buffer[tail%q.maxSize] = val
+	q.tail.Store(tail + 1)
+	return true
+}
+
+func (q *SPSCQueue[T]) Dequeue() (T, bool) {
+	var empty T
+	head := q.head.Load()
+	if head >= q.cachedTail {
+		q.cachedTail = q.tail.Load()
+		if head >= q.cachedTail {
+			return empty, false
+		}
+	}
+
+	idx := head % q.maxSize
+	val := q.buffer[idx]
+	q.buffer[idx] = empty
+	q.head.Store(head + 1)
+	return val, true
+}
+
+// Peek returns the element at the front of the queue without removing it.
+// Like Dequeue, it must only be called by the consumer.
+func (q *SPSCQueue[T]) Peek() (T, bool) {
+	head := q.head.Load()
+	if head >= q.cachedTail {
+		q.cachedTail = q.tail.Load()
+		if head >= q.cachedTail {
+			var empty T
+			return empty, false
+		}
+	}
+
+	return q.buffer[head%q.maxSize], true
+}
+
+// EnqueueN adds as many elements of vals as fit, in order, and returns the number added.
+func (q *SPSCQueue[T]) EnqueueN(vals []T) int {
+	tail := q.tail.Load()
+	free := q.maxSize - (tail - q.cachedHead)
+	if free < uint64(len(vals)) {
+		q.cachedHead = q.head.Load()
+		free = q.maxSize - (tail - q.cachedHead)
+	}
+
+	n := min(uint64(len(vals)), free)
+	for idx := range n {
+		q.buffer[(tail+idx)%q.maxSize] = vals[idx]
+	}
+
+	q.tail.Store(tail + n)
+	return int(n)
+}
+
+// DequeueN removes up to len(dst) elements into dst and returns the number removed.
+func (q *SPSCQueue[T]) DequeueN(dst []T) int {
+	head := q.head.Load()
+	available := q.cachedTail - head
+	if q.cachedTail < head || available < uint64(len(dst)) {
+		q.cachedTail = q.tail.Load()
+		available = q.cachedTail - head
+	}
+
+	var empty T
+	n := min(uint64(len(dst)), available)
+	for idx := range n {
+		slot := (head + idx) % q.maxSize
+		dst[idx] = q.buffer[slot]
+		q.buffer[slot] = empty
+	}
+
+	q.head.Store(head + n)
+	return int(n)
+}
+
+// All returns an iterator over the elements from front to back without removing them.
+// Elements enqueued while iterating may or may not be included.
+func (q *SPSCQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for pos := q.head.Load(); pos < q.tail.Load(); pos++ {
+			if !yield(q.buffer[pos%q.maxSize]) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that dequeues elements until the queue is empty.
+func (q *SPSCQueue[T]) Drain() iter.Seq[T] {
+	return drain[T](q)
+}
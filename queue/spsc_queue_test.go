@@ -0,0 +1,126 @@
+package queue
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSPSCQueue_Batch(t *testing.T) {
+	q := NewSPSCQueue[int](defaultQSize)
+	assert.Equal(t, 3, q.EnqueueN([]int{0, 1, 2}))
+	assert.Equal(t, 2, q.EnqueueN([]int{3, 4, 5, 6}))
+	assert.Equal(t, 0, q.EnqueueN([]int{7}))
+	assert.Equal(t, defaultQSize, q.Len())
+
+	dst := make([]int, 2)
+	assert.Equal(t, 2, q.DequeueN(dst))
+	assert.Equal(t, []int{0, 1}, dst)
+
+	// Wrap around the end of the buffer.
+	assert.Equal(t, 2, q.EnqueueN([]int{5, 6, 7}))
+
+	dst = make([]int, 10)
+	assert.Equal(t, defaultQSize, q.DequeueN(dst))
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, dst[:defaultQSize])
+	assert.Equal(t, 0, q.DequeueN(dst))
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestSPSCQueue_ZeroSize(t *testing.T) {
+	q := NewSPSCQueue[int](0)
+	assert.False(t, q.Enqueue(1))
+	assert.Equal(t, 0, q.EnqueueN([]int{1}))
+	_, ok := q.Dequeue()
+	assert.False(t, ok)
+	assert.Equal(t, 0, q.DequeueN(make([]int, 1)))
+}
+
+func TestSPSCQueue_Concurrent(t *testing.T) {
+	tests := map[string]struct {
+		batch int
+	}{
+		"single":  {batch: 1},
+		"batched": {batch: 7},
+	}
+
+	const count = 100000
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			q := NewSPSCQueue[int](16)
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				buf := make([]int, 0, tc.batch)
+				for next := 0; next < count; {
+					buf = buf[:0]
+					for idx := next; idx < min(next+tc.batch, count); idx++ {
+						buf = append(buf, idx)
+					}
+
+					n := q.EnqueueN(buf)
+					if n == 0 {
+						runtime.Gosched()
+					}
+					next += n
+				}
+			}()
+
+			dst := make([]int, tc.batch)
+			for expected := 0; expected < count; {
+				n := q.DequeueN(dst)
+				if n == 0 {
+					runtime.Gosched()
+					continue
+				}
+
+				for _, v := range dst[:n] {
+					if v != expected {
+						t.Fatalf("expected %d, got %d", expected, v)
+					}
+					expected++
+				}
+			}
+			wg.Wait()
+
+			assert.Equal(t, 0, q.Len())
+		})
+	}
+}
+
+func BenchmarkSPSCPipeline(b *testing.B) {
+	const size = 1024
+	queues := map[string]func() Queue[int]{
+		"LockQueue": qWrap(NewLockQueue[int], size),
+		"MPMCQueue": qWrap(NewMPMCQueue[int], size),
+		"SPSCQueue": qWrap(NewSPSCQueue[int], size),
+	}
+
+	for name, fn := range queues {
+		b.Run(name, func(b *testing.B) {
+			q := fn()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for range b.N {
+					for {
+						if _, ok := q.Dequeue(); ok {
+							break
+						}
+						runtime.Gosched()
+					}
+				}
+			}()
+
+			for idx := range b.N {
+				for !q.Enqueue(idx) {
+					runtime.Gosched()
+				}
+			}
+			<-done
+		})
+	}
+}
@@ -1,5 +1,14 @@
 package queue
 
+// Unbounded can be passed as the size of a queue to let it grow without limit.
+const Unbounded = -1
+
+// isFull reports whether a queue holding len elements is full.
+// A negative maxSize means the queue is unbounded.
+func isFull(len, maxSize int) bool {
+	return maxSize >= 0 && len >= maxSize
+}
+
 type Queue[T any] interface {
 
 	// Enqueue adds the element to the end of the queue.
@@ -11,6 +20,15 @@ type Queue[T any] interface {
 	Dequeue() (T, bool)
 }
 
+type PeekQueue[T any] interface {
+	// Peek returns the element at the front of the queue without removing it.
+	// Returns false if the queue is empty
+	Peek() (T, bool)
+
+	// Should also implement other queue methods.
+	Queue[T]
+}
+
 type LenQueue[T any] interface {
 	// Len returns the length of the queue.
 	Len() int
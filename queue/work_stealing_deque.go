@@ -0,0 +1,137 @@
+package queue
+
+import "sync/atomic"
+
+const minWorkStealingSize = 16
+
+// circularArray is a fixed size buffer indexed modulo its length.
+// Elements are stored behind atomic pointers as thieves may read a slot while the owner writes it.
+type circularArray[T any] struct {
+	buffer []atomic.Pointer[T]
+	mask   int64
+}
+
+func newCircularArray[T any](size int64) *circularArray[T] {
+	return &circularArray[T]{buffer: make([]atomic.Pointer[T], size), mask: size - 1}
+}
+
+func (a *circularArray[T]) size() int64 {
+	return int64(len(a.buffer))
+}
+
+func (a *circularArray[T]) get(idx int64) *T {
+	return a.buffer[idx&a.mask].Load()
+}
+
+func (a *circularArray[T]) put(idx int64, val *T) {
+	a.buffer[idx&a.mask].Store(val)
+}
+
+// grow returns a copy of the elements in [top, bottom) in an array twice the size.
+func (a *circularArray[T]) grow(top, bottom int64) *circularArray[T] {
+	grown := newCircularArray[T](a.size() * 2)
+	for idx := top; idx < bottom; idx++ {
+		grown.put(idx, a.get(idx))
+	}
+
+	return grown
+}
+
+// WorkStealingDeque is an unbounded lock-free Chase-Lev deque.
+// A single owner goroutine pushes and pops at the bottom, while any number of thieves steal from the top.
+// PushBottom and PopBottom must only be called by the owner.
+type WorkStealingDeque[T any] struct {
+	_      [cacheLineSize]byte
+	top    atomic.Int64
+	_      [cacheLineSize - 8]byte
+	bottom atomic.Int64
+	_      [cacheLineSize - 8]byte
+	array  atomic.Pointer[circularArray[T]]
+}
+
+// NewWorkStealingDeque creates an empty deque with room for at least capacity elements before it has to grow.
+func NewWorkStealingDeque[T any](capacity int) *WorkStealingDeque[T] {
+	size := int64(minWorkStealingSize)
+	for size < int64(capacity) {
+		size *= 2
+	}
+
+	d := &WorkStealingDeque[T]{}
+	d.array.Store(newCircularArray[T](size))
+	return d
+}
+
+// PushBottom adds val to the bottom of the deque.
+func (d *WorkStealingDeque[T]) PushBottom(val T) {
+	bottom := d.bottom.Load()
+	top := d.top.Load()
+	array := d.array.Load()
+
+	if bottom-top >= array.size() {
+		array = array.grow(top, bottom)
+		d.array.Store(array)
+	}
+
+	array.put(bottom, &val)
+	d.bottom.Store(bottom + 1)
+}
+
+// PopBottom removes and returns the element at the bottom of the deque.
+// Returns false if the deque is empty or the last element was stolen.
+func (d *WorkStealingDeque[T]) PopBottom() (T, bool) {
+	var empty T
+
+	// Reserve the bottom element before checking for thieves.
+	bottom := d.bottom.Load() - 1
+	array := d.array.Load()
+	d.bottom.Store(bottom)
+	top := d.top.Load()
+
+	if top > bottom {
+		d.bottom.Store(bottom + 1)
+		return empty, false
+	}
+
+	val := array.get(bottom)
+	if top < bottom {
+		array.put(bottom, nil)
+		return *val, true
+	}
+
+	// This is the last element, so race the thieves for it.
+	won := d.top.CompareAndSwap(top, top+1)
+	d.bottom.Store(bottom + 1)
+	if !won {
+		return empty, false
+	}
+
+	return *val, true
+}
+
+// Steal removes and returns the element at the top of the deque.
+// It is safe to call from any goroutine. Returns false if the deque is empty.
+func (d *WorkStealingDeque[T]) Steal() (T, bool) {
+	for {
+		top := d.top.Load()
+		bottom := d.bottom.Load()
+		if top >= bottom {
+			var empty T
+			return empty, false
+		}
+
+		// The slot may be overwritten once top moves past it,
+		// so the value is only used if this thief claims it.
+		val := d.array.Load().get(top)
+		if d.top.CompareAndSwap(top, top+1) {
+			return *val, true
+		}
+	}
+}
+
+// Len returns the number of elements in the deque.
+// The result is only a snapshot when other goroutines are using the deque.
+func (d *WorkStealingDeque[T]) Len() int {
+	bottom := d.bottom.Load()
+	top := d.top.Load()
+	return int(max(bottom-top, 0))
+}
@@ -0,0 +1,175 @@
+package queue
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkStealingDeque(t *testing.T) {
+	tests := map[string]func(t *testing.T, d *WorkStealingDeque[int]){
+		"Empty": func(t *testing.T, d *WorkStealingDeque[int]) {
+			_, ok := d.PopBottom()
+			assert.False(t, ok)
+			_, ok = d.Steal()
+			assert.False(t, ok)
+			assert.Equal(t, 0, d.Len())
+		},
+		"Owner is LIFO": func(t *testing.T, d *WorkStealingDeque[int]) {
+			for idx := range 100 {
+				d.PushBottom(idx)
+			}
+			assert.Equal(t, 100, d.Len())
+
+			for idx := range 100 {
+				v, ok := d.PopBottom()
+				assert.True(t, ok)
+				assert.Equal(t, 99-idx, v)
+			}
+			assert.Equal(t, 0, d.Len())
+		},
+		"Thieves are FIFO": func(t *testing.T, d *WorkStealingDeque[int]) {
+			for idx := range 100 {
+				d.PushBottom(idx)
+			}
+
+			for idx := range 100 {
+				v, ok := d.Steal()
+				assert.True(t, ok)
+				assert.Equal(t, idx, v)
+			}
+			_, ok := d.Steal()
+			assert.False(t, ok)
+		},
+		"Both ends": func(t *testing.T, d *WorkStealingDeque[int]) {
+			for idx := range 3 {
+				d.PushBottom(idx)
+			}
+
+			v, _ := d.Steal()
+			assert.Equal(t, 0, v)
+			v, _ = d.PopBottom()
+			assert.Equal(t, 2, v)
+			v, _ = d.PopBottom()
+			assert.Equal(t, 1, v)
+
+			_, ok := d.PopBottom()
+			assert.False(t, ok)
+			_, ok = d.Steal()
+			assert.False(t, ok)
+		},
+		"Grows while wrapped": func(t *testing.T, d *WorkStealingDeque[int]) {
+			for idx := range minWorkStealingSize {
+				d.PushBottom(idx)
+			}
+			for range minWorkStealingSize / 2 {
+				d.Steal()
+			}
+			for idx := range minWorkStealingSize * 2 {
+				d.PushBottom(minWorkStealingSize + idx)
+			}
+
+			for idx := minWorkStealingSize / 2; idx < minWorkStealingSize*3; idx++ {
+				v, ok := d.Steal()
+				assert.True(t, ok)
+				assert.Equal(t, idx, v)
+			}
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc(t, NewWorkStealingDeque[int](0))
+		})
+	}
+}
+
+func TestWorkStealingDeque_Concurrent(t *testing.T) {
+	tests := map[string]struct {
+		thieves int
+		popRate int
+	}{
+		"1 thief":               {thieves: 1, popRate: 2},
+		"4 thieves":             {thieves: 4, popRate: 3},
+		"16 thieves":            {thieves: 16, popRate: 2},
+		"thieves only":          {thieves: 8, popRate: 0},
+		"owner pops every time": {thieves: 4, popRate: 1},
+	}
+
+	const count = 50000
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := NewWorkStealingDeque[int](0)
+			seen := make([]atomic.Int32, count)
+			taken := atomic.Int64{}
+
+			wg := sync.WaitGroup{}
+			wg.Add(tc.thieves)
+			for range tc.thieves {
+				go func() {
+					defer wg.Done()
+					for taken.Load() < count {
+						v, ok := d.Steal()
+						if !ok {
+							runtime.Gosched()
+							continue
+						}
+						seen[v].Add(1)
+						taken.Add(1)
+					}
+				}()
+			}
+
+			for idx := range count {
+				d.PushBottom(idx)
+				if tc.popRate > 0 && idx%tc.popRate == 0 {
+					if v, ok := d.PopBottom(); ok {
+						seen[v].Add(1)
+						taken.Add(1)
+					}
+				}
+			}
+
+			// The owner helps draining whatever the thieves have not taken yet.
+			for taken.Load() < count {
+				if v, ok := d.PopBottom(); ok {
+					seen[v].Add(1)
+					taken.Add(1)
+				}
+			}
+			wg.Wait()
+
+			for v := range seen {
+				assert.Equal(t, int32(1), seen[v].Load(), "value %d", v)
+			}
+			assert.Equal(t, 0, d.Len())
+		})
+	}
+}
+
+func BenchmarkWorkStealingDeque(b *testing.B) {
+	b.Run("Owner", func(b *testing.B) {
+		d := NewWorkStealingDeque[int](0)
+		for idx := range b.N {
+			d.PushBottom(idx)
+			d.PopBottom()
+		}
+	})
+
+	b.Run("Steal", func(b *testing.B) {
+		d := NewWorkStealingDeque[int](0)
+		for idx := range b.N {
+			d.PushBottom(idx)
+		}
+
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				d.Steal()
+			}
+		})
+	})
+}
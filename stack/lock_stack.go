@@ -1,11 +1,17 @@
 package stack
 
-import "sync"
+import (
+	"iter"
+	"slices"
+	"sync"
+)
 
 var (
 	_ Stack[int] = (*LockStack[int])(nil)
 )
 
+// LockStack is a thread-safe stack backed by a slice.
+// It holds at most maxSize elements, or any number of elements if maxSize is Unbounded.
 type LockStack[T any] struct {
 	buffer  []T
 	mux     *sync.Mutex
@@ -14,7 +20,7 @@ type LockStack[T any] struct {
 
 func NewLockStack[T any](len int) *LockStack[T] {
 	return &LockStack[T]{
-		buffer:  make([]T, 0, len),
+		buffer:  make([]T, 0, max(len, 0)),
 		mux:     &sync.Mutex{},
 		maxSize: len,
 	}
@@ -27,29 +33,110 @@ func (s *LockStack[T]) Len() int {
 }
 
 func (s *LockStack[T]) Push(val T) bool {
-	if s.Len() == s.maxSize {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.maxSize >= 0 && len(s.buffer) >= s.maxSize {
 		return false
 	}
 
-	s.mux.Lock()
 	s.buffer = append(s.buffer, val)
-	s.mux.Unlock()
-
 	return true
 }
 
 func (s *LockStack[T]) Pop() (T, bool) {
-	len := s.Len()
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	var empty T
+	len := len(s.buffer)
 	if len == 0 {
-		var empty T
 		return empty, false
 	}
 
-	s.mux.Lock()
 	last := len - 1
 	val := s.buffer[last]
+	s.buffer[last] = empty
 	s.buffer = s.buffer[:last]
-	s.mux.Unlock()
 
 	return val, true
 }
+
+func (s *LockStack[T]) Peek() (T, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if len(s.buffer) == 0 {
+		var empty T
+		return empty, false
+	}
+
+	return s.buffer[len(s.buffer)-1], true
+}
+
+// Snapshot returns a copy of the elements from top to bottom.
+func (s *LockStack[T]) Snapshot() []T {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	snapshot := slices.Clone(s.buffer)
+	slices.Reverse(snapshot)
+	return snapshot
+}
+
+// PushAll pushes as many elements of vals as fit, in order, under a single lock.
+// Returns the number of elements pushed, which are always a prefix of vals.
+func (s *LockStack[T]) PushAll(vals []T) int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	n := len(vals)
+	if s.maxSize >= 0 {
+		n = min(n, s.maxSize-len(s.buffer))
+	}
+
+	s.buffer = append(s.buffer, vals[:n]...)
+	return n
+}
+
+// PopN pops up to len(dst) elements into dst under a single lock, starting from the top.
+// Returns the number of elements popped.
+func (s *LockStack[T]) PopN(dst []T) int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	n := min(len(dst), len(s.buffer))
+	last := len(s.buffer) - 1
+	for idx := range n {
+		dst[idx] = s.buffer[last-idx]
+	}
+
+	start := len(s.buffer) - n
+	clear(s.buffer[start:])
+	s.buffer = s.buffer[:start]
+	return n
+}
+
+// All returns an iterator over a snapshot of the elements from top to bottom.
+// The lock is not held while the consumer runs.
+func (s *LockStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.Snapshot() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops elements until the stack is empty.
+func (s *LockStack[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := s.Pop()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
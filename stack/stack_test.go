@@ -1,6 +1,7 @@
 package stack
 
 import (
+	"slices"
 	"sync"
 	"testing"
 
@@ -23,6 +24,7 @@ func TestStackCorrectness(t *testing.T) {
 	tests := map[string]testFn[int]{
 		"FILO Property":      testFiloCond,
 		"Length Correctness": testLenCond,
+		"Peek Correctness":   testPeekCond,
 	}
 
 	for name, fn := range stacks {
@@ -128,3 +130,105 @@ func TestStackParallel(t *testing.T) {
 		})
 	}
 }
+
+func TestStackIterators(t *testing.T) {
+	s := NewLockStack[int](defaultStackSize)
+	assert.Empty(t, slices.Collect(s.All()))
+
+	for idx := range defaultStackSize {
+		assert.True(t, s.Push(idx))
+	}
+
+	expected := []int{4, 3, 2, 1, 0}
+	assert.Equal(t, expected, slices.Collect(s.All()))
+	assert.Equal(t, defaultStackSize, s.Len())
+
+	for v := range s.Drain() {
+		if v == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []int{2, 1, 0}, slices.Collect(s.Drain()))
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestUnboundedStack(t *testing.T) {
+	const count = 10000
+	s := NewLockStack[int](Unbounded)
+	for idx := range count {
+		assert.True(t, s.Push(idx))
+	}
+	assert.Equal(t, count, s.Len())
+
+	for idx := range count {
+		v, ok := s.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, count-idx-1, v)
+	}
+
+	_, ok := s.Pop()
+	assert.False(t, ok)
+}
+
+func TestLockStack_Batch(t *testing.T) {
+	s := NewLockStack[int](defaultStackSize)
+	assert.Equal(t, 0, s.PopN(make([]int, 2)))
+
+	assert.Equal(t, 3, s.PushAll([]int{0, 1, 2}))
+	assert.Equal(t, 2, s.PushAll([]int{3, 4, 5}))
+	assert.Equal(t, 0, s.PushAll([]int{6}))
+	assert.Equal(t, defaultStackSize, s.Len())
+
+	dst := make([]int, 2)
+	assert.Equal(t, 2, s.PopN(dst))
+	assert.Equal(t, []int{4, 3}, dst)
+
+	assert.True(t, s.Push(5))
+	dst = make([]int, 10)
+	assert.Equal(t, 4, s.PopN(dst))
+	assert.Equal(t, []int{5, 2, 1, 0}, dst[:4])
+	assert.Equal(t, 0, s.Len())
+
+	unbounded := NewLockStack[int](Unbounded)
+	assert.Equal(t, 1000, unbounded.PushAll(make([]int, 1000)))
+}
+
+func testPeekCond(t *testing.T, s LenStack[int]) {
+	_, ok := s.Peek()
+	assert.False(t, ok)
+
+	for idx := range defaultStackSize {
+		assert.True(t, s.Push(idx))
+
+		item, ok := s.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, idx, item)
+		assert.Equal(t, idx+1, s.Len())
+	}
+
+	for range defaultStackSize {
+		peeked, ok := s.Peek()
+		assert.True(t, ok)
+
+		item, _ := s.Pop()
+		assert.Equal(t, item, peeked)
+	}
+
+	_, ok = s.Peek()
+	assert.False(t, ok)
+}
+
+func TestLockStack_Snapshot(t *testing.T) {
+	s := NewLockStack[int](defaultStackSize)
+	assert.Empty(t, s.Snapshot())
+
+	s.PushAll([]int{0, 1, 2})
+	snapshot := s.Snapshot()
+	assert.Equal(t, []int{2, 1, 0}, snapshot)
+
+	// The snapshot is a copy that is unaffected by later changes.
+	snapshot[0] = 10
+	s.Pop()
+	assert.Equal(t, []int{1, 0}, s.Snapshot())
+	assert.Equal(t, []int{10, 1, 0}, snapshot)
+}
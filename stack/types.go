@@ -1,5 +1,8 @@
 package stack
 
+// Unbounded can be passed as the size of a stack to let it grow without limit.
+const Unbounded = -1
+
 type Stack[T any] interface {
 	// Push pushes the element to the top of the stack.
 	// Return true if the push is successful
@@ -8,6 +11,10 @@ type Stack[T any] interface {
 	// Pop returns the element at the top and removes it.
 	// Returns false if the stack is empty
 	Pop() (val T, ok bool)
+
+	// Peek returns the element at the top without removing it.
+	// Returns false if the stack is empty
+	Peek() (val T, ok bool)
 }
 
 type LenStack[T any] interface {
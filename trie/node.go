@@ -1,6 +1,9 @@
 package trie
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+)
 
 type Node struct {
 	next   [255]*Node
@@ -67,6 +70,44 @@ func (n *Node) GetPrefixWords(prefix string) []string {
 	return n.next[start].GetPrefixWords(prefix[1:])
 }
 
+// All returns an iterator over the words below the node in lexicographic order.
+func (n *Node) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		n.walk(make([]byte, 0), yield)
+	}
+}
+
+func (n *Node) walk(prefix []byte, yield func(string) bool) bool {
+	if n.hasVal && !yield(string(prefix)) {
+		return false
+	}
+
+	for letter, nodeVal := range n.next {
+		if nodeVal == nil {
+			continue
+		}
+
+		if !nodeVal.walk(append(prefix, byte(letter)), yield) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (n *Node) find(prefix string) *Node {
+	if len(prefix) == 0 {
+		return n
+	}
+
+	node := n.next[prefix[0]]
+	if node == nil {
+		return nil
+	}
+
+	return node.find(prefix[1:])
+}
+
 func (n *Node) GetAllWords() []string {
 	acc := make([]string, 0)
 
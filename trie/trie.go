@@ -1,6 +1,9 @@
 package trie
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+)
 
 type Trie struct {
 	head *Node
@@ -38,6 +41,23 @@ func (t *Trie) GetAllWords() []string {
 	return t.head.GetAllWords()
 }
 
+// All returns an iterator over all words in lexicographic order.
+func (t *Trie) All() iter.Seq[string] {
+	return t.head.All()
+}
+
+// Completions returns an iterator over the words starting with prefix in lexicographic order.
+func (t *Trie) Completions(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		node := t.head.find(prefix)
+		if node == nil {
+			return
+		}
+
+		node.walk([]byte(prefix), yield)
+	}
+}
+
 func (t *Trie) Print() {
 	fmt.Print("Trie:{")
 	t.head.Print()
@@ -1,6 +1,7 @@
 package trie
 
 import (
+	"slices"
 	"testing"
 	"time"
 	"unsafe"
@@ -62,6 +63,25 @@ func TestTrie(t *testing.T) {
 	}
 }
 
+func TestTrie_Iterators(t *testing.T) {
+	trie := NewTrie("tea", "ten", "te", "a", "inn", "to")
+
+	assert.Equal(t, []string{"a", "inn", "te", "tea", "ten", "to"}, slices.Collect(trie.All()))
+	assert.Equal(t, trie.GetAllWords(), slices.Collect(trie.All()))
+	assert.Equal(t, []string{"te", "tea", "ten"}, slices.Collect(trie.Completions("te")))
+	assert.Empty(t, slices.Collect(trie.Completions("x")))
+	assert.Empty(t, slices.Collect(NewTrie().All()))
+
+	result := make([]string, 0)
+	for word := range trie.All() {
+		if word == "te" {
+			break
+		}
+		result = append(result, word)
+	}
+	assert.Equal(t, []string{"a", "inn"}, result)
+}
+
 const lenItems = 20
 
 func BenchmarkTrie_Write(b *testing.B) {